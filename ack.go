@@ -0,0 +1,168 @@
+package textio
+
+import (
+	"context"
+	"io"
+	"sync"
+)
+
+// Checkpoint tracks the progress of [Reader.StreamAck] for at-least-once processing.
+//
+// Its offset is the input offset up to which every token was acknowledged, and only
+// advances past a token once it and all the tokens before it are acknowledged.
+// Persisting the offset (see [Checkpoint.OnAdvance]) and passing it to [NewCheckpoint]
+// after a restart resumes the stream right after the tokens known to be processed;
+// tokens that were sent but not acknowledged are sent again.
+//
+// A Checkpoint is safe for concurrent use.
+type Checkpoint struct {
+	mu     sync.Mutex
+	offset int64
+	// base is the sequence number of the first pending token, and ends and acked
+	// the end offsets and acknowledgement of the pending tokens.
+	base      int
+	ends      []int64
+	acked     []bool
+	onAdvance func(offset int64)
+}
+
+// NewCheckpoint returns a [Checkpoint] at offset, usually the last offset
+// persisted by a previous run, or 0 to start from the beginning of the input.
+func NewCheckpoint(offset int64) *Checkpoint {
+	return &Checkpoint{offset: offset}
+}
+
+// Offset returns the offset up to which the input was processed.
+func (c *Checkpoint) Offset() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.offset
+}
+
+// OnAdvance sets f to be called with the new offset each time the checkpoint advances,
+// typically to persist it. Calls are made in order, from the goroutine calling
+// [AckToken.Ack], and f must not acknowledge tokens itself.
+func (c *Checkpoint) OnAdvance(f func(offset int64)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.onAdvance = f
+}
+
+// track registers a token ending at end and returns its sequence number.
+func (c *Checkpoint) track(end int64) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ends = append(c.ends, end)
+	c.acked = append(c.acked, false)
+	return c.base + len(c.ends) - 1
+}
+
+// ack acknowledges the token with sequence number seq.
+func (c *Checkpoint) ack(seq int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	i := seq - c.base
+	if i < 0 || i >= len(c.acked) || c.acked[i] {
+		return
+	}
+	c.acked[i] = true
+	n := 0
+	for n < len(c.acked) && c.acked[n] {
+		n++
+	}
+	if n == 0 {
+		return
+	}
+	c.offset = c.ends[n-1]
+	c.ends = c.ends[n:]
+	c.acked = c.acked[n:]
+	c.base += n
+	if c.onAdvance != nil {
+		c.onAdvance(c.offset)
+	}
+}
+
+// AckToken is a token sent by [Reader.StreamAck].
+type AckToken struct {
+	// Text is the token.
+	Text string
+	// Offset is the input offset right after the token.
+	Offset int64
+
+	cp  *Checkpoint
+	seq int
+}
+
+// Ack acknowledges that the token was processed. Tokens may be acknowledged
+// in any order, and acknowledging a token again has no effect.
+func (t AckToken) Ack() {
+	if t.cp != nil {
+		t.cp.ack(t.seq)
+	}
+}
+
+// StreamAck streams tokens to out like [Reader.StreamTokens], to be acknowledged
+// with [AckToken.Ack] once processed. The offset of cp only advances past
+// acknowledged tokens, see [Checkpoint].
+//
+// Tokens ending at or before the offset of cp are skipped, so the input must be the
+// same as when the offset was recorded. Offsets are counted over all the sources of
+// the [Reader], from the start of the first. Skipped tokens are not read again when
+// the input is a single seekable source, such as a file, which is then moved to the
+// offset of cp directly, the delimiter found there not being a leading delimiter
+// (see [Delimiter.SetLeading]). Skipped tokens do not count toward the limits of
+// [Reader.SetLimit] and [Reader.SetMaxTokens], nor in the indexes of the tokens sent,
+// which start at 0.
+// The optional opts override the [Reader] configuration for this call only.
+func (r *Reader) StreamAck(ctx context.Context, cp *Checkpoint, out chan<- AckToken, opts ...ReadOption) error {
+	skip := cp.Offset()
+	r = r.apply(opts)
+	seeked := skip > 0 && r.seekInput(skip)
+	if seeked && r.delimiter != nil {
+		// The input now starts on the delimiter following the last acknowledged
+		// token, which is not a leading delimiter.
+		d := *r.delimiter
+		d.SetLeading(EmptySkip)
+		resumed := *r
+		resumed.delimiter = &d
+		r = &resumed
+	}
+	it := r.iter()
+	defer it.close()
+	it.skipTo = skip
+	if seeked {
+		it.pos, it.start = skip, skip
+	}
+	for {
+		token, err := it.next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		end := it.start + int64(len(it.raw))
+		select {
+		case out <- AckToken{Text: token, Offset: end, cp: cp, seq: cp.track(end)}:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// seekInput moves the input of r forward by off bytes before anything is read from it,
+// and reports whether it did. Only inputs made of a single seekable source, read as is,
+// are moved: other offsets do not map to positions in a source.
+func (r *Reader) seekInput(off int64) bool {
+	ms := r.input()
+	if ms == nil || len(ms.readers) != 1 || ms.read > 0 || r.schedule.mode != scheduleSequential ||
+		r.codec != nil || r.charset != nil || r.byteRange != nil {
+		return false
+	}
+	s, ok := seeker(ms.readers[0])
+	if !ok {
+		return false
+	}
+	_, err := s.Seek(off, io.SeekCurrent)
+	return err == nil
+}
@@ -0,0 +1,51 @@
+package textio
+
+import "sync/atomic"
+
+// minAdaptiveBuffer is the initial scan buffer size of an adaptive [Reader]
+// that has not read any token yet.
+const minAdaptiveBuffer = 512
+
+// SetAdaptiveBuffer controls whether the scan buffer is sized from the tokens read.
+//
+// By default, each read allocates a scan buffer of MaxTokenSize bytes up front. In
+// adaptive mode, it starts with a buffer sized from a running average of the lengths
+// of the tokens read so far, at least 512 bytes and at most MaxTokenSize, which reduces
+// memory for short-token workloads. The buffer grows as needed while reading, up to
+// the usual limit (see [Reader.MaxBufferSize]), and long-token workloads soon start
+// with a buffer large enough to avoid regrowing it.
+//
+// The average is shared by the copies of the [Reader] made after the call.
+func (r *Reader) SetAdaptiveBuffer(adaptive bool) {
+	r.adaptive = nil
+	if adaptive {
+		r.adaptive = &tokenSizeEstimate{}
+	}
+}
+
+// tokenSizeEstimate is a running average of token lengths.
+type tokenSizeEstimate struct {
+	// avg is the exponentially weighted average length, in 1/16 bytes.
+	avg atomic.Int64
+}
+
+// observe accounts for a token of n bytes.
+func (e *tokenSizeEstimate) observe(n int) {
+	for {
+		old := e.avg.Load()
+		if e.avg.CompareAndSwap(old, old+(int64(n)<<4-old)/8) {
+			return
+		}
+	}
+}
+
+// bufferSize returns the initial size of a scan buffer, room for several tokens
+// of average length, rounded up to a power of two, and capped at limit.
+func (e *tokenSizeEstimate) bufferSize(limit int) int {
+	want := 8 * int(e.avg.Load()>>4)
+	size := minAdaptiveBuffer
+	for size < want && size < limit {
+		size *= 2
+	}
+	return min(size, limit)
+}
@@ -0,0 +1,45 @@
+package textio
+
+import "unsafe"
+
+// tokenArenaSize is the size of the arenas holding the tokens of a [Reader]
+// in low allocation mode.
+const tokenArenaSize = 16 << 10
+
+// SetLowAlloc controls whether tokens are read in low allocation mode, for high
+// throughput pipelines where garbage collection is the bottleneck.
+//
+// By default, every token read is a new string. In low allocation mode, tokens are
+// carved out of shared 16KiB arenas, so reading and streaming allocate once per arena
+// instead of once per token. Together with the default normalizer, which does not
+// allocate, [Reader.StreamTokens] then makes at most one allocation per emitted token;
+// normalizers, filters and hooks making their own allocations add to that count.
+//
+// Tokens stay valid and immutable, but a token kept by the consumer keeps its whole
+// arena alive: consumers keeping a few tokens out of many should copy them (see
+// [strings.Clone]).
+func (r *Reader) SetLowAlloc(lowAlloc bool) {
+	r.lowAlloc = lowAlloc
+}
+
+// text returns the token read by the last call to it.tokens.Scan.
+func (it *tokenIter) text() string {
+	if !it.r.lowAlloc {
+		return it.tokens.Text()
+	}
+	src, ok := it.tokens.(interface{ Bytes() []byte })
+	if !ok {
+		return it.tokens.Text()
+	}
+	b := src.Bytes()
+	if len(b) == 0 {
+		return ""
+	}
+	if len(b) > cap(it.arena)-len(it.arena) {
+		it.arena = make([]byte, 0, max(tokenArenaSize, len(b)))
+	}
+	start := len(it.arena)
+	// The arena is only ever appended to, so its bytes never change once written.
+	it.arena = append(it.arena, b...)
+	return unsafe.String(&it.arena[start], len(b))
+}
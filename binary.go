@@ -0,0 +1,114 @@
+package textio
+
+import (
+	"fmt"
+	"io"
+	"unicode"
+	"unicode/utf8"
+)
+
+// BinaryPolicy decides what a [Reader] does with sources whose content looks binary.
+type BinaryPolicy int
+
+const (
+	// BinaryAllow tokenizes every source without inspecting it. This is the default.
+	BinaryAllow BinaryPolicy = iota
+	// BinarySkip silently skips sources that look binary.
+	BinarySkip
+	// BinaryFail makes reads fail with [ErrBinaryInput] on the first source that looks binary.
+	BinaryFail
+)
+
+// sniffLen is the maximum number of leading bytes inspected to detect binary content.
+const sniffLen = 512
+
+// maxEmptyReads is the number of reads returning no data and no error
+// after which a source is considered empty while sniffing it.
+const maxEmptyReads = 100
+
+// minPrintableRatio is the minimum ratio of printable runes of a text source.
+const minPrintableRatio = 0.7
+
+// SetBinaryPolicy sets the policy applied to sources whose content looks binary,
+// such as images or executables met while reading a directory.
+//
+// The first bytes of each source are inspected: a source containing NUL bytes
+// or with a low ratio of printable characters is considered binary. Only the bytes
+// returned by the first read of the source are inspected, up to 512, so that
+// interactive sources such as terminals or followed files are not blocked waiting
+// for more input; a source delivering its content a few bytes at a time is
+// judged on those bytes.
+func (r *Reader) SetBinaryPolicy(p BinaryPolicy) {
+	r.binary = p
+}
+
+// binarySourceError is returned by a [multiSource] when a source looks binary
+// under the [BinaryFail] policy.
+type binarySourceError struct {
+	source string
+}
+
+func (e *binarySourceError) Error() string {
+	return fmt.Sprintf("source %s looks binary", e.source)
+}
+
+// sniff reads the first bytes of the current source into m.pending and
+// reports whether the source must be skipped under the binary policy of m.
+func (m *multiSource) sniff() (bool, error) {
+	// A single read is made, as waiting for sniffLen bytes would block
+	// interactive or followed sources until they produce that much input.
+	buf := make([]byte, sniffLen)
+	n, err := 0, error(nil)
+	for range maxEmptyReads {
+		if n, err = m.src.Read(buf); n > 0 || err != nil {
+			break
+		}
+	}
+	if err != nil && err != io.EOF {
+		return false, err
+	}
+	buf = buf[:n]
+
+	if !isBinary(buf) {
+		m.pending = buf
+		return false, nil
+	}
+	if m.binary == BinarySkip {
+		return true, nil
+	}
+
+	name := sourceName(m.readers[m.current])
+	if name == "" {
+		name = fmt.Sprintf("#%d", m.current)
+	} else {
+		name = fmt.Sprintf("%q", name)
+	}
+	return false, &binarySourceError{source: name}
+}
+
+// isBinary reports whether data looks like binary content:
+// it contains a NUL byte, or too few of its runes are printable.
+func isBinary(data []byte) bool {
+	if len(data) == 0 {
+		return false
+	}
+
+	printable, total := 0, 0
+	for len(data) > 0 {
+		if !utf8.FullRune(data) {
+			// Rune cut by the end of the sniffed bytes.
+			break
+		}
+		c, size := utf8.DecodeRune(data)
+		data = data[size:]
+		total++
+		switch {
+		case c == 0:
+			return true
+		case c == utf8.RuneError && size == 1:
+		case unicode.IsPrint(c) || unicode.IsSpace(c):
+			printable++
+		}
+	}
+	return total > 0 && float64(printable)/float64(total) < minPrintableRatio
+}
@@ -0,0 +1,17 @@
+package textio
+
+import "golang.org/x/text/encoding"
+
+// SetEncoding makes the [Reader] transcode each of its sources from enc to UTF-8
+// before tokenizing it, for inputs such as UTF-16 or Windows-1252 files, for example:
+//
+//	r.SetEncoding(unicode.UTF16(unicode.LittleEndian, unicode.UseBOM))
+//	r.SetEncoding(charmap.Windows1252)
+//
+// Sources are transcoded after being decoded by the codec, if any (see [Reader.SetCodec]).
+// Invalid input is replaced as specified by enc, usually with U+FFFD.
+// Offsets reported in [TokenInfo] refer to the transcoded data.
+// A nil enc, the default, reads the sources as UTF-8.
+func (r *Reader) SetEncoding(enc encoding.Encoding) {
+	r.charset = enc
+}
@@ -0,0 +1,252 @@
+// Command textio tokenizes text read from files or standard input
+// with the textio package and prints the resulting tokens.
+//
+// Usage:
+//
+//	textio [flags] [file ...]
+//
+// Without file arguments, standard input is read. For example, to print
+// the words longer than 3 characters of a file in upper case:
+//
+//	textio -re '\s+' -norm trim,upper -min 4 words.txt
+//
+// Delimiter flags accept Go escape sequences such as \t, \n or \x00.
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/JFinlayM/textio"
+	"github.com/JFinlayM/textio/filters"
+	"github.com/JFinlayM/textio/normalizers"
+)
+
+type config struct {
+	delim       string
+	re          string
+	stop        string
+	norm        string
+	match       string
+	minLen      int
+	maxLen      int
+	limit       int
+	skip        int
+	out         string
+	failInvalid bool
+}
+
+func main() {
+	cfg, paths, err := parseFlags(os.Args[1:], os.Stderr)
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		os.Exit(2)
+	}
+
+	if err := run(cfg, paths, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "textio:", err)
+		os.Exit(1)
+	}
+}
+
+// parseFlags parses the command line arguments args, writing usage and errors to
+// output, and returns the configuration and the file arguments.
+func parseFlags(args []string, output io.Writer) (config, []string, error) {
+	var cfg config
+	fs := flag.NewFlagSet("textio", flag.ContinueOnError)
+	fs.SetOutput(output)
+	fs.StringVar(&cfg.delim, "d", `\n`, "token delimiter string")
+	fs.StringVar(&cfg.re, "re", "", "token delimiter regular expression (overrides -d)")
+	fs.StringVar(&cfg.stop, "stop", "", "stop delimiter string, reading ends where it is found")
+	fs.StringVar(&cfg.norm, "norm", "trim", "comma separated normalizers applied in order: trim, upper, lower, none")
+	fs.StringVar(&cfg.match, "match", "", "keep only tokens matching this regular expression")
+	fs.IntVar(&cfg.minLen, "min", 0, "keep only tokens of at least this length")
+	fs.IntVar(&cfg.maxLen, "max", 0, "keep only tokens of at most this length (0 means no maximum)")
+	fs.IntVar(&cfg.limit, "limit", 0, "print at most this many tokens (0 means no limit)")
+	fs.IntVar(&cfg.skip, "skip", 0, "skip this many accepted tokens before printing")
+	fs.StringVar(&cfg.out, "o", `\n`, "output delimiter written after each token")
+	fs.BoolVar(&cfg.failInvalid, "strict", false, "fail on the first token rejected by a filter")
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "usage: textio [flags] [file ...]\n")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return config{}, nil, err
+	}
+	return cfg, fs.Args(), nil
+}
+
+func run(cfg config, paths []string, stdout io.Writer) error {
+	rc := textio.NewReaderCloser()
+	defer rc.Close()
+
+	if len(paths) > 0 {
+		var readers []io.Reader
+		for _, path := range paths {
+			f, err := os.Open(path)
+			if err != nil {
+				for _, r := range readers {
+					r.(io.Closer).Close()
+				}
+				return err
+			}
+			readers = append(readers, f)
+		}
+		rc.SetReaders(readers...)
+	}
+
+	d, err := delimiter(cfg)
+	if err != nil {
+		return err
+	}
+	rc.SetDelimiter(d)
+
+	n, err := normalizer(cfg.norm)
+	if err != nil {
+		return err
+	}
+	rc.SetNormalizer(n)
+
+	f, err := filter(cfg)
+	if err != nil {
+		return err
+	}
+	rc.SetFilter(f)
+	rc.FailOnInvalid = cfg.failInvalid
+
+	out, err := unescape(cfg.out)
+	if err != nil {
+		return fmt.Errorf("-o: %w", err)
+	}
+
+	var opts []textio.ReadOption
+	if cfg.limit > 0 {
+		opts = append(opts, textio.WithLimit(cfg.limit+cfg.skip))
+	}
+
+	w := bufio.NewWriter(stdout)
+	ch := make(chan string, 64)
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- rc.StreamTokens(context.Background(), ch, opts...)
+		close(ch)
+	}()
+
+	i := 0
+	for tok := range ch {
+		i++
+		if i <= cfg.skip {
+			continue
+		}
+		w.WriteString(tok)
+		w.WriteString(out)
+	}
+
+	return errors.Join(<-errCh, w.Flush())
+}
+
+func delimiter(cfg config) (*textio.Delimiter, error) {
+	d := textio.NewDelimiter()
+	if cfg.re != "" {
+		re, err := regexp.Compile(cfg.re)
+		if err != nil {
+			return nil, fmt.Errorf("-re: %w", err)
+		}
+		d.SetTokenRegexp(re)
+	} else {
+		s, err := unescape(cfg.delim)
+		if err != nil {
+			return nil, fmt.Errorf("-d: %w", err)
+		}
+		d.SetTokenStr(s)
+	}
+
+	stop, err := unescape(cfg.stop)
+	if err != nil {
+		return nil, fmt.Errorf("-stop: %w", err)
+	}
+	d.SetStopStr(stop)
+	return d, nil
+}
+
+func normalizer(list string) (textio.NormalizeFunc, error) {
+	var ns []textio.NormalizeFunc
+	for _, name := range strings.Split(list, ",") {
+		switch strings.TrimSpace(name) {
+		case "trim":
+			ns = append(ns, textio.NormalizeTrimSpace)
+		case "upper":
+			ns = append(ns, normalizers.Upper)
+		case "lower":
+			ns = append(ns, normalizers.Lower)
+		case "none", "":
+		default:
+			return nil, fmt.Errorf("-norm: unknown normalizer %q", name)
+		}
+	}
+	if len(ns) == 0 {
+		return nil, nil
+	}
+	return textio.ChainNormalizers(ns...), nil
+}
+
+func filter(cfg config) (textio.FilterFunc, error) {
+	var f textio.FilterFunc
+	and := func(g textio.FilterFunc) {
+		if f == nil {
+			f = g
+		} else {
+			f = f.And(g)
+		}
+	}
+
+	if cfg.minLen > 0 {
+		and(filters.MinLength(cfg.minLen))
+	}
+	if cfg.maxLen > 0 {
+		and(filters.MaxLength(cfg.maxLen))
+	}
+	if cfg.match != "" {
+		re, err := regexp.Compile(cfg.match)
+		if err != nil {
+			return nil, fmt.Errorf("-match: %w", err)
+		}
+		and(filters.Regexp(re))
+	}
+	return f, nil
+}
+
+// unescape interprets the Go escape sequences of s, such as \t or \x00.
+// Double quotes may be escaped or not.
+func unescape(s string) (string, error) {
+	var sb strings.Builder
+	for s != "" {
+		if s[0] == '"' {
+			sb.WriteByte('"')
+			s = s[1:]
+			continue
+		}
+		r, multibyte, tail, err := strconv.UnquoteChar(s, '"')
+		if err != nil {
+			return "", fmt.Errorf("invalid escape sequence in %q", s)
+		}
+		if r < utf8.RuneSelf || !multibyte {
+			sb.WriteByte(byte(r))
+		} else {
+			sb.WriteRune(r)
+		}
+		s = tail
+	}
+	return sb.String(), nil
+}
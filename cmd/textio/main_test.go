@@ -0,0 +1,173 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseFlags(t *testing.T) {
+	cfg, paths, err := parseFlags(nil, io.Discard)
+	if err != nil {
+		t.Fatalf("parseFlags() error: %v", err)
+	}
+	want := config{delim: `\n`, norm: "trim", out: `\n`}
+	if cfg != want {
+		t.Errorf("default config = %+v, want %+v", cfg, want)
+	}
+	if len(paths) != 0 {
+		t.Errorf("paths = %q, want none", paths)
+	}
+
+	cfg, paths, err = parseFlags([]string{
+		"-d", ",", "-re", `\s+`, "-stop", "END", "-norm", "trim,upper", "-match", "^a",
+		"-min", "2", "-max", "5", "-limit", "3", "-skip", "1", "-o", ";", "-strict",
+		"a.txt", "b.txt",
+	}, io.Discard)
+	if err != nil {
+		t.Fatalf("parseFlags() error: %v", err)
+	}
+	want = config{
+		delim: ",", re: `\s+`, stop: "END", norm: "trim,upper", match: "^a",
+		minLen: 2, maxLen: 5, limit: 3, skip: 1, out: ";", failInvalid: true,
+	}
+	if cfg != want {
+		t.Errorf("config = %+v, want %+v", cfg, want)
+	}
+	if !reflect.DeepEqual(paths, []string{"a.txt", "b.txt"}) {
+		t.Errorf("paths = %q, want [a.txt b.txt]", paths)
+	}
+
+	if _, _, err := parseFlags([]string{"-min", "x"}, io.Discard); err == nil {
+		t.Error("parseFlags(-min x) succeeded, want an error")
+	}
+	var usage bytes.Buffer
+	if _, _, err := parseFlags([]string{"-h"}, &usage); !errors.Is(err, flag.ErrHelp) {
+		t.Errorf("parseFlags(-h) error = %v, want flag.ErrHelp", err)
+	}
+	if !strings.HasPrefix(usage.String(), "usage: textio") {
+		t.Errorf("usage = %q", usage.String())
+	}
+}
+
+func TestUnescape(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{``, ""},
+		{`,`, ","},
+		{`\n`, "\n"},
+		{`\t|\x00`, "\t|\x00"},
+		{`é`, "é"},
+		{`\xff`, "\xff"},
+		{`"`, `"`},
+		{`\"`, `"`},
+		{`a"b\"c`, `a"b"c`},
+		{`\\"`, `\"`},
+	}
+	for _, tt := range tests {
+		got, err := unescape(tt.in)
+		if err != nil {
+			t.Errorf("unescape(%q) error: %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("unescape(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+
+	for _, in := range []string{`\`, `\q`, `\x0`} {
+		if _, err := unescape(in); err == nil {
+			t.Errorf("unescape(%q) succeeded, want an error", in)
+		}
+	}
+}
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	lines := write("lines.txt", " a \nbb\nccc\ndddd\n")
+	csv := write("fields.csv", `x,"y",z`)
+	words := write("words.txt", "one  two\tthree\nfour")
+
+	tests := []struct {
+		name  string
+		args  []string
+		paths []string
+		want  string
+	}{
+		{"defaults", nil, []string{lines}, "a\nbb\nccc\ndddd\n"},
+		{"delimiter", []string{"-d", ","}, []string{csv}, "x\n\"y\"\nz\n"},
+		{"quote delimiter", []string{"-d", `\"`}, []string{csv}, "x,\ny\n,z\n"},
+		{"regex", []string{"-re", `\s+`}, []string{words}, "one\ntwo\nthree\nfour\n"},
+		{"stop", []string{"-stop", "ccc"}, []string{lines}, "a\nbb\n"},
+		{"normalizers", []string{"-norm", "none"}, []string{lines}, " a \nbb\nccc\ndddd\n"},
+		{"upper", []string{"-norm", "trim,upper"}, []string{lines}, "A\nBB\nCCC\nDDDD\n"},
+		{"filters", []string{"-min", "2", "-max", "3"}, []string{lines}, "bb\nccc\n"},
+		{"match", []string{"-match", "^c"}, []string{lines}, "ccc\n"},
+		{"skip", []string{"-skip", "2"}, []string{lines}, "ccc\ndddd\n"},
+		{"limit", []string{"-limit", "2"}, []string{lines}, "a\nbb\n"},
+		{"skip and limit", []string{"-skip", "1", "-limit", "2"}, []string{lines}, "bb\nccc\n"},
+		{"output delimiter", []string{"-o", `\t`}, []string{lines}, "a\tbb\tccc\tdddd\t"},
+		{"several files", []string{"-o", ";"}, []string{lines, csv}, "a;bb;ccc;dddd;x,\"y\",z;"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, _, err := parseFlags(tt.args, io.Discard)
+			if err != nil {
+				t.Fatalf("parseFlags() error: %v", err)
+			}
+			var out bytes.Buffer
+			if err := run(cfg, tt.paths, &out); err != nil {
+				t.Fatalf("run() error: %v", err)
+			}
+			if out.String() != tt.want {
+				t.Errorf("output = %q, want %q", out.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestRun_Errors(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "in.txt")
+	if err := os.WriteFile(path, []byte("a\nbb\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		args  []string
+		paths []string
+	}{
+		{"missing file", nil, []string{filepath.Join(t.TempDir(), "missing")}},
+		{"bad regex", []string{"-re", "("}, []string{path}},
+		{"bad match", []string{"-match", "("}, []string{path}},
+		{"bad escape", []string{"-d", `\q`}, []string{path}},
+		{"bad output escape", []string{"-o", `\q`}, []string{path}},
+		{"unknown normalizer", []string{"-norm", "title"}, []string{path}},
+		{"strict", []string{"-strict", "-min", "2"}, []string{path}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, _, err := parseFlags(tt.args, io.Discard)
+			if err != nil {
+				t.Fatalf("parseFlags() error: %v", err)
+			}
+			if err := run(cfg, tt.paths, io.Discard); err == nil {
+				t.Error("run() succeeded, want an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package textio
+
+import "github.com/JFinlayM/textio/internal/core"
+
+// Codec encodes and decodes byte streams, such as a compression format.
+//
+// Codecs are registered by name with [RegisterCodec], and used on [Reader] sources
+// with [Reader.SetCodec] and on [Writer] sinks with [Writer.SetCodec], so compressed
+// round-trips are configured declaratively. The "gzip", "zstd" and "none" codecs are
+// built in, as well as "bzip2" and "auto" (see [WrapCompression]) for decoding only;
+// others can be registered by applications.
+type Codec = core.Codec
+
+func init() {
+	core.RegisterCodec("auto", autoCodec{})
+}
+
+// RegisterCodec registers c under name, replacing any codec of the same name.
+// It is safe for concurrent use.
+func RegisterCodec(name string, c Codec) {
+	core.RegisterCodec(name, c)
+}
+
+// CodecByName returns the codec registered under name.
+func CodecByName(name string) (Codec, bool) {
+	return core.CodecByName(name)
+}
+
+// SetCodec makes the [Reader] decode each of its sources with the codec registered
+// under name, for example "gzip" to read compressed files. Decoding errors are read
+// errors. Offsets reported in [TokenInfo] refer to the decoded data.
+func (r *Reader) SetCodec(name string) error {
+	c, err := core.LookupCodec(name)
+	if err != nil {
+		return err
+	}
+	r.codec = c
+	return nil
+}
@@ -0,0 +1,112 @@
+package textio
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// compressionMagics are the leading bytes identifying compressed data, and the
+// name of the codec decoding it.
+var compressionMagics = []struct {
+	magic []byte
+	codec string
+}{
+	{[]byte{0x1f, 0x8b}, "gzip"},
+	{[]byte("BZh"), "bzip2"},
+	{[]byte{0x28, 0xb5, 0x2f, 0xfd}, "zstd"},
+}
+
+// WrapCompression returns a reader decompressing r if its data starts with the magic
+// bytes of gzip, bzip2 or zstd, and r itself, or a reader returning the same data,
+// otherwise. It reads the first bytes of r to identify the format; seekable readers
+// are rewound after that, and returned as is when not compressed.
+//
+// Data is decompressed with the codec registered under the name of the format, see
+// [RegisterCodec]. The returned reader has the name of r (see [TokenInfo]), and
+// closing it closes r if r is an [io.Closer].
+//
+// [ReaderCloser.FromFile] wraps files with WrapCompression, and the "auto" codec
+// applies it to every source of a [Reader], see [Reader.SetCodec].
+func WrapCompression(r io.Reader) (io.Reader, error) {
+	head, src, err := peekHead(r, 4)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range compressionMagics {
+		if !bytes.HasPrefix(head, m.magic) {
+			continue
+		}
+		c, ok := CodecByName(m.codec)
+		if !ok {
+			return nil, fmt.Errorf("textio: %s input requires a codec registered as %q", m.codec, m.codec)
+		}
+		dec, err := c.Decode(src)
+		if err != nil {
+			return nil, err
+		}
+		return &decompressed{Reader: dec, src: r}, nil
+	}
+	if src == r {
+		return r, nil
+	}
+	return &decompressed{Reader: src, src: r}, nil
+}
+
+// peekHead returns the first n bytes of r, or less if r is shorter, and a reader
+// returning the whole data of r, which is r itself if it is seekable.
+func peekHead(r io.Reader, n int) ([]byte, io.Reader, error) {
+	if s, ok := seeker(r); ok {
+		if off, err := s.Seek(0, io.SeekCurrent); err == nil {
+			head := make([]byte, n)
+			m, err := io.ReadFull(r, head)
+			if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+				return nil, nil, err
+			}
+			if _, err := s.Seek(off, io.SeekStart); err != nil {
+				return nil, nil, err
+			}
+			return head[:m], r, nil
+		}
+	}
+	br := bufio.NewReader(r)
+	head, err := br.Peek(n)
+	if err != nil && err != io.EOF {
+		return nil, nil, err
+	}
+	return head, br, nil
+}
+
+// decompressed reads the data of src through Reader, keeping the name of src.
+type decompressed struct {
+	io.Reader
+	src io.Reader
+}
+
+func (d *decompressed) Name() string {
+	return sourceName(d.src)
+}
+
+// Close closes the decompressor and src, when they are [io.Closer] values.
+func (d *decompressed) Close() error {
+	var errs []error
+	if c, ok := d.Reader.(io.Closer); ok {
+		errs = append(errs, c.Close())
+	}
+	if c, ok := d.src.(io.Closer); ok {
+		errs = append(errs, c.Close())
+	}
+	return errors.Join(errs...)
+}
+
+type autoCodec struct{}
+
+func (autoCodec) Decode(r io.Reader) (io.Reader, error) {
+	return WrapCompression(r)
+}
+
+func (autoCodec) Encode(w io.Writer) (io.WriteCloser, error) {
+	return nil, errors.New("textio: auto codec cannot encode")
+}
@@ -0,0 +1,67 @@
+package textio
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/JFinlayM/textio/internal/core"
+)
+
+// ConfigError is a problem found by [Reader.CheckConfig] in the configuration of a [Reader].
+type ConfigError struct {
+	// Setting is the setting at fault, such as "delimiter" or "MaxTokenSize".
+	Setting string
+	// Reason tells what is wrong with it.
+	Reason string
+}
+
+func (e *ConfigError) Error() string {
+	return e.Setting + ": " + e.Reason
+}
+
+func (e *ConfigError) Is(target error) bool {
+	return target == ErrConfig
+}
+
+// CheckConfig validates the configuration of the [Reader] before any reading starts,
+// so mistakes are reported up front rather than as failures or panics in the middle of
+// a stream. It returns nil for a valid configuration, and otherwise an [ErrConfig]
+// error wrapping a [*ConfigError] for each problem found:
+//   - a nil delimiter, or a delimiter without token pattern;
+//   - token and stop patterns that conflict, the stop pattern never matching;
+//   - regular expression patterns matching the empty string;
+//   - no input source;
+//   - a MaxTokenSize of 0 or less;
+//   - more priority levels than sources in the [Schedule].
+//
+// Nil functions in [ChainNormalizers] and in filters combined with [FilterFunc.And],
+// [FilterFunc.Or] and [Not] are ignored when reading, and are not reported.
+func (r *Reader) CheckConfig() error {
+	var errs []error
+	report := func(setting, format string, args ...any) {
+		errs = append(errs, &ConfigError{Setting: setting, Reason: fmt.Sprintf(format, args...)})
+	}
+
+	if d := r.delimiter; d == nil {
+		report("delimiter", "is nil")
+	} else {
+		for _, reason := range core.Problems(d) {
+			report("delimiter", "%s", reason)
+		}
+	}
+
+	if r.source == nil && len(r.sources) == 0 {
+		report("readers", "no input source")
+	}
+	if r.MaxTokenSize <= 0 {
+		report("MaxTokenSize", "%d is not positive", r.MaxTokenSize)
+	}
+	if n := len(r.schedule.priorities); n > len(r.sources) {
+		report("schedule", "%d priority levels for %d sources", n, len(r.sources))
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return newErrConfig(errors.Join(errs...))
+}
@@ -0,0 +1,53 @@
+package textio
+
+import "strings"
+
+// SetContinuation makes the [Reader] join each token ending with marker to the next
+// token, as a line ending with "\" continues on the next line in shell scripts and
+// configuration files. The marker is removed, and the tokens are joined without the
+// delimiter between them, before normalization: with "\" as marker, the lines
+// "a \" and "b" make the token "a b". A marker ending the input is removed.
+//
+// Joined tokens count as one token, located at the first of them (see [TokenInfo]).
+// A marker is always a continuation, even if preceded by an escape such as "\".
+// An empty marker, the default, disables line continuation.
+func (r *Reader) SetContinuation(marker string) {
+	r.continuation = marker
+}
+
+// joinContinued returns token joined to the next content tokens while it ends
+// with the continuation marker, see [Reader.SetContinuation].
+func (it *tokenIter) joinContinued(token string) string {
+	marker := it.r.continuation
+	if !strings.HasSuffix(token, marker) {
+		return token
+	}
+	start := it.start
+	cur := -1
+	if it.sched != nil {
+		// Only the tokens of the same source continue the token.
+		cur, it.sched.hold = it.sched.cur, true
+		defer func() { it.sched.hold = false }()
+	}
+	var sb strings.Builder
+	for strings.HasSuffix(token, marker) {
+		sb.WriteString(token[:len(token)-len(marker)])
+		token = ""
+		for it.tokens.Scan() {
+			if it.sched != nil {
+				if it.sched.cur != cur {
+					it.sched.unscan()
+					break
+				}
+				it.kind = it.sched.item.kind
+			}
+			if it.kind != KindDelimiter {
+				token = it.text()
+				break
+			}
+		}
+	}
+	sb.WriteString(token)
+	it.start, it.kind = start, KindContent
+	return sb.String()
+}
@@ -0,0 +1,107 @@
+package textio
+
+import (
+	"context"
+	"errors"
+
+	"github.com/JFinlayM/textio/internal/core"
+)
+
+// RespondErrorPolicy is what [Converse] does when the respond function fails.
+type RespondErrorPolicy int
+
+const (
+	// RespondFail stops the conversation and returns the error. This is the default.
+	RespondFail RespondErrorPolicy = iota
+	// RespondSkip writes no response and goes on with the next token.
+	RespondSkip
+	// RespondReply writes the error message as the response and goes on.
+	RespondReply
+)
+
+// ErrEndConversation can be returned by the respond function of [Converse] to end
+// the conversation without error, after writing the response returned along with it
+// if not empty, as for a "quit" command.
+var ErrEndConversation = errors.New("textio: end of conversation")
+
+// ConverseOption configures [Converse].
+type ConverseOption func(*conversation)
+
+// WithRespondErrors returns a [ConverseOption] setting the policy applied
+// when the respond function fails. The default is [RespondFail].
+func WithRespondErrors(p RespondErrorPolicy) ConverseOption {
+	return func(c *conversation) {
+		c.policy = p
+	}
+}
+
+type conversation struct {
+	policy RespondErrorPolicy
+}
+
+// Converse runs a prompt/response loop: it reads each token of in, computes a response
+// with respond and writes it to out, flushing it right away so the peer sees it. This is
+// a small framework for line-protocol servers and interactive command-line tools.
+// When out is a [Writer], each response is ended by the joiner of its delimiter,
+// such as "\n", so the peer gets complete lines:
+//
+//	err := textio.Converse(ctx, textio.NewReader().Source(), respond, textio.NewWriter(os.Stdout))
+//
+// The conversation ends without error at the end of in, or when respond returns
+// [ErrEndConversation]. Errors of respond are handled according to the policy set with
+// [WithRespondErrors]; errors of in and out are returned. ctx is checked before each
+// token and returns ctx.Err() once done, but does not interrupt a blocked read of in.
+// in is closed when the conversation ends, if it has a Close method.
+// Delimiter tokens of in (see [Delimiter.SetEmitDelimiters]) are not responded to.
+func Converse(ctx context.Context, in TokenSource, respond func(string) (string, error), out TokenWriter, opts ...ConverseOption) error {
+	c := &conversation{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	defer closeSource(in)
+	ks, _ := in.(kindSource)
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if !in.Scan() {
+			return in.Err()
+		}
+		if ks != nil && ks.Kind() == KindDelimiter {
+			continue
+		}
+
+		response, err := respond(in.Text())
+		end := errors.Is(err, ErrEndConversation)
+		if end {
+			if response == "" {
+				return nil
+			}
+		} else if err != nil {
+			switch c.policy {
+			case RespondSkip:
+				continue
+			case RespondReply:
+				response = err.Error()
+			default:
+				return err
+			}
+		}
+
+		if err := out.WriteToken(Token{Text: response}); err != nil {
+			return err
+		}
+		if w, ok := out.(*Writer); ok {
+			// End the response now rather than before the next one.
+			if err := w.WriteToken(Token{Text: core.JoinSep(w.Delimiter()), Kind: KindDelimiter}); err != nil {
+				return err
+			}
+		}
+		if err := out.Flush(); err != nil {
+			return err
+		}
+		if end {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package textio
+
+import (
+	"encoding"
+	"errors"
+	"io"
+	"reflect"
+)
+
+var textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+
+// DecodeTokens reads the tokens of r and decodes them into dst, which must be a pointer
+// to a slice whose elements implement [encoding.TextUnmarshaler] (such as []net.IP,
+// []big.Int or []*MyType). Decoded values are appended to the slice.
+//
+// A token that fails to decode stops the read with [ErrInvalid]; the returned
+// [ReaderError] holds the token, its index among the tokens read, and the
+// unmarshaling error. Values decoded before a failure are kept in dst.
+func (r *Reader) DecodeTokens(dst any, opts ...ReadOption) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Slice {
+		return errors.New("textio: DecodeTokens destination must be a non-nil pointer to a slice")
+	}
+	slice := v.Elem()
+	elem := slice.Type().Elem()
+	isPtr := elem.Kind() == reflect.Pointer && elem.Implements(textUnmarshalerType)
+	if !isPtr && !reflect.PointerTo(elem).Implements(textUnmarshalerType) {
+		return errors.New("textio: DecodeTokens elements must implement encoding.TextUnmarshaler")
+	}
+
+	it := r.apply(opts).iter()
+	defer it.close()
+	for index := 0; ; index++ {
+		token, err := it.next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		var val reflect.Value
+		if isPtr {
+			val = reflect.New(elem.Elem())
+		} else {
+			val = reflect.New(elem)
+		}
+		if err := val.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(token)); err != nil {
+			return it.decodeError(token, index, err)
+		}
+		if !isPtr {
+			val = val.Elem()
+		}
+		slice.Set(reflect.Append(slice, val))
+	}
+}
@@ -1,188 +1,63 @@
 package textio
 
-import (
-	"bufio"
-	"bytes"
-	"regexp"
+import "github.com/JFinlayM/textio/delimiters"
+
+// Delimiter tells how the input of a [Reader] is split into tokens.
+// See package [github.com/JFinlayM/textio/delimiters] for its methods.
+type Delimiter = delimiters.Delimiter
+
+// EmptyPolicy decides what happens to an empty token produced by a delimiter.
+type EmptyPolicy = delimiters.EmptyPolicy
+
+const (
+	// EmptyEmit emits the empty token. This is the default.
+	EmptyEmit = delimiters.EmptyEmit
+	// EmptySkip drops the empty token.
+	EmptySkip = delimiters.EmptySkip
+	// EmptyError fails the read.
+	EmptyError = delimiters.EmptyError
 )
 
-type Delimiter struct {
-	token pattern
-	stop  pattern
-}
-
-// By contruction, [regexpr] and [str] cannot be set at the same time.
-type pattern struct {
-	// Delimiter as a regular expression
-	re *regexp.Regexp
-	// String delimiter
-	str string
-}
-
 // Default configuration delimiter provider. Default delimiter is "\n" (line-based seperation).
 func NewDelimiter() *Delimiter {
-	return DefaultDelimiter()
+	return delimiters.New()
 }
 
 func DefaultDelimiter() *Delimiter {
-	return &Delimiter{
-		token: pattern{str: "\n"},
-		stop:  pattern{str: "\n\n"},
-	}
-}
-
-// Sets the regexpr delimiter.
-// This resets the [str] field of `d`.
-func (d *Delimiter) SetTokenRegexp(regexpr *regexp.Regexp) {
-	d.token.re = regexpr
-	d.token.str = ""
-}
-
-// Sets the [str] field of `d` used to seperate input into tokens.
-// This resets the [delimiter] field of `d`.
-func (d *Delimiter) SetTokenStr(s string) {
-	d.token.re = nil
-	d.token.str = s
-}
-
-// Sets the regexpr delimiter from an expression in string format.
-// This resets the [str] field of `d`.
-// This function will panic if the expression cannot compile.
-func (d *Delimiter) SetTokenRegexpFromString(expr string) {
-	if expr == "" {
-		panic("empty regexp is not allowed")
-	}
-	regexpr := regexp.MustCompile(expr)
-	d.token.re = regexpr
-	d.token.str = ""
-}
-
-// Sets the regexpr delimiter.
-// This resets the [str] field of `d`.
-func (d *Delimiter) SetStopRegexp(regexpr *regexp.Regexp) {
-	d.stop.re = regexpr
-	d.stop.str = ""
-}
-
-// Sets the [str] field of `d` used to seperate input into tokens.
-// This resets the [delimiter] field of `d`.
-func (d *Delimiter) SetStopStr(s string) {
-	d.stop.re = nil
-	d.stop.str = s
-}
-
-// Sets the regexpr delimiter from an expression in string format.
-// This resets the [str] field of `d`.
-// This function will panic if the expression cannot compile.
-func (d *Delimiter) SetStopRegexpFromString(expr string) {
-	if expr == "" {
-		panic("empty regexp is not allowed")
-	}
-	regexpr := regexp.MustCompile(expr)
-	d.stop.re = regexpr
-	d.stop.str = ""
-}
-
-func (d Delimiter) WithTokenRegexp(regexpr *regexp.Regexp) *Delimiter {
-	d.token = pattern{re: regexpr}
-	return &d
-}
-
-func (d Delimiter) WithTokenStr(s string) *Delimiter {
-	d.token = pattern{str: s}
-	return &d
-}
-
-func (d Delimiter) WithTokenRegexpFromString(s string) *Delimiter {
-	if s == "" {
-		panic("empty regexp is not allowed")
-	}
-	d.token = pattern{re: regexp.MustCompile(s)}
-	return &d
-}
-
-func (d Delimiter) WithStopRegexp(regexpr *regexp.Regexp) *Delimiter {
-	d.stop = pattern{re: regexpr}
-	return &d
-}
-
-func (d Delimiter) WithStopStr(s string) *Delimiter {
-	d.stop = pattern{str: s}
-	return &d
-}
-
-func (d Delimiter) WithStopRegexpFromString(s string) *Delimiter {
-	if s == "" {
-		panic("empty regexp is not allowed")
-	}
-
-	d.stop = pattern{re: regexp.MustCompile(s)}
-	return &d
-}
-
-func (d *Delimiter) SplitFunc() bufio.SplitFunc {
-	return func(data []byte, atEOF bool) (advance int, token []byte, err error) {
-
-		// Nothing left
-		if atEOF && len(data) == 0 {
-			return 0, nil, bufio.ErrFinalToken
-		}
-
-		// Locate delimiters
-		tokenIdx, tokenW := d.token.find(data)
-
-		stopIdx, stopW := -1, 0
-		if d.stop.enabled() {
-			stopIdx, stopW = d.stop.find(data)
-		}
-
-		if stopIdx >= 0 && (tokenIdx < 0 || stopIdx < tokenIdx) {
-			// Return data before stop as final token
-			if stopIdx > 0 {
-				return stopIdx, data[:stopIdx], nil
-			}
-
-			// Stop delimiter at beginning: consume and stop
-			return stopW, nil, bufio.ErrFinalToken
-		}
-
-		if tokenIdx >= 0 {
-			return tokenIdx + tokenW, data[:tokenIdx], nil
-		}
-
-		if atEOF {
-			return len(data), data, nil
-		}
-
-		// Need more data
-		return 0, nil, nil
-	}
-}
-
-func (p pattern) enabled() bool {
-	return p.re != nil || p.str != ""
-}
-
-func (p *pattern) find(data []byte) (idx int, width int) {
-	if p == nil {
-		return -1, 0
-	}
-
-	if p.re != nil {
-		loc := p.re.FindIndex(data)
-		if loc == nil {
-			return -1, 0
-		}
-		return loc[0], loc[1] - loc[0]
-	}
-
-	if p.str != "" {
-		idx := bytes.Index(data, []byte(p.str))
-		if idx < 0 {
-			return -1, 0
-		}
-		return idx, len(p.str)
-	}
-
-	return -1, 0
+	return delimiters.Default()
+}
+
+// NullDelimiter returns a [Delimiter] separating tokens with NUL bytes ("\x00") and
+// without stop pattern, for consuming `find -print0` or `xargs -0` style streams
+// where tokens, such as file names, may contain newlines.
+//
+// File names may also start or end with spaces: use a nil normalizer
+// rather than the default [NormalizeTrimSpace] to keep them intact.
+func NullDelimiter() *Delimiter {
+	return delimiters.Null()
+}
+
+// WordsPreset returns a [Delimiter] splitting the input into words like [bufio.ScanWords]:
+// tokens are separated by runs of Unicode white space, and leading or trailing
+// white space does not produce empty tokens. There is no stop pattern.
+func WordsPreset() *Delimiter {
+	return delimiters.Words()
+}
+
+// FieldsPreset returns a [Delimiter] splitting the input on runs of sep, such as the
+// spaces padding the columns of a fixed-width report, without producing empty tokens.
+// Tokens are joined back with a single sep (see [Join]). There is no stop pattern.
+func FieldsPreset(sep string) *Delimiter {
+	return delimiters.Fields(sep)
+}
+
+// ScanBytes returns a [Delimiter] emitting every byte of the input as its own token,
+// for binary-ish protocols where each byte is inspected with the normalization and
+// filtering functions of the [Reader].
+//
+// Stop patterns are not supported in this mode. Setting a token pattern on the
+// returned [Delimiter] switches it back to pattern based splitting.
+// Note that the default [NormalizeTrimSpace] turns whitespace bytes into empty tokens.
+func ScanBytes() *Delimiter {
+	return delimiters.Bytes()
 }
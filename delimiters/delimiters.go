@@ -0,0 +1,207 @@
+// Package delimiters provides the [Delimiter] type, which tells how an input is split
+// into tokens, its presets and [Join], for programs that only need to split or join
+// tokens without reading them with package [github.com/JFinlayM/textio].
+//
+// Package textio re-exports the contents of this package under its usual names,
+// such as textio.Delimiter and textio.CSVPreset.
+package delimiters
+
+import (
+	"bufio"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/JFinlayM/textio/internal/core"
+)
+
+// Delimiter describes how an input is split into tokens: its token pattern, an
+// optional stop pattern ending the input, and how tokens are joined back.
+// The zero Delimiter has no token pattern; use [New] or one of the presets.
+type Delimiter = core.Delimiter
+
+// EmptyPolicy decides what happens to an empty token produced by a delimiter,
+// see [Delimiter.SetLeading].
+type EmptyPolicy = core.EmptyPolicy
+
+const (
+	// EmptyEmit emits the empty token. This is the default.
+	EmptyEmit = core.EmptyEmit
+	// EmptySkip drops the empty token.
+	EmptySkip = core.EmptySkip
+	// EmptyError fails the read.
+	EmptyError = core.EmptyError
+)
+
+// Errors returned by the split functions of delimiters, see [Delimiter.SetLeading]
+// and [Delimiter.SetMatchLimit].
+var (
+	ErrLeadingDelimiter = core.ErrLeadingDelimiter
+	ErrPatternTimeout   = core.ErrPatternTimeout
+)
+
+var (
+	presetsMu sync.RWMutex
+	presets   = map[string]*Delimiter{
+		"lines":      Lines(),
+		"words":      Words(),
+		"csv":        CSV(),
+		"tsv":        TSV(),
+		"paragraphs": Paragraphs(),
+		"null":       Null(),
+	}
+)
+
+// Register registers d under name, replacing any preset of the same name,
+// so applications can select their tokenization by name from configuration files.
+// Later changes to d do not affect the registered preset.
+// It is safe for concurrent use.
+func Register(name string, d *Delimiter) {
+	c := *d
+	presetsMu.Lock()
+	defer presetsMu.Unlock()
+	presets[name] = &c
+}
+
+// ByName returns a copy of the [Delimiter] registered under name, which can be
+// modified freely. The "lines", "words", "csv", "tsv", "paragraphs" and "null"
+// presets are built in, see [Lines], [Words], [CSV], [TSV], [Paragraphs] and [Null].
+func ByName(name string) (*Delimiter, bool) {
+	presetsMu.RLock()
+	defer presetsMu.RUnlock()
+	d, ok := presets[name]
+	if !ok {
+		return nil, false
+	}
+	c := *d
+	return &c, true
+}
+
+// New returns the [Default] delimiter.
+func New() *Delimiter {
+	return Default()
+}
+
+// Default returns a [Delimiter] separating tokens with "\n" (line-based separation),
+// with "\n\n" as stop pattern.
+func Default() *Delimiter {
+	d := &Delimiter{}
+	d.SetTokenStr("\n")
+	d.SetStopStr("\n\n")
+	return d
+}
+
+// Null returns a [Delimiter] separating tokens with NUL bytes ("\x00") and
+// without stop pattern, for consuming `find -print0` or `xargs -0` style streams
+// where tokens, such as file names, may contain newlines.
+//
+// File names may also start or end with spaces: use a nil normalizer
+// rather than the default TrimSpace one to keep them intact.
+func Null() *Delimiter {
+	d := &Delimiter{}
+	d.SetTokenStr("\x00")
+	return d
+}
+
+// Lines returns a [Delimiter] splitting the input into lines like [bufio.ScanLines],
+// without the "\n\n" stop pattern of the [Default] delimiter.
+func Lines() *Delimiter {
+	d := &Delimiter{}
+	d.SetTokenStr("\n")
+	return d
+}
+
+// Words returns a [Delimiter] splitting the input into words like [bufio.ScanWords]:
+// tokens are separated by runs of Unicode white space, and leading or trailing
+// white space does not produce empty tokens. There is no stop pattern.
+func Words() *Delimiter {
+	d := &Delimiter{}
+	d.SetTokenFunc(unicode.IsSpace)
+	d.SetLeading(EmptySkip)
+	d.SetJoiner(" ")
+	return d
+}
+
+// Fields returns a [Delimiter] splitting the input on runs of sep, such as the
+// spaces padding the columns of a fixed-width report, without producing empty tokens.
+// Tokens are joined back with a single sep (see [Join]). There is no stop pattern.
+func Fields(sep string) *Delimiter {
+	d := &Delimiter{}
+	d.SetTokenStr(sep)
+	d.CollapseRuns(true)
+	d.SetJoiner(sep)
+	return d
+}
+
+// CSV returns a [Delimiter] splitting comma-separated values into fields: tokens
+// are separated by commas and line endings, except inside double quotes (see
+// [Delimiter.SetQuote]), and joined back with commas. Fields keep their quotes,
+// which an unquoting normalizer removes. There is no stop pattern.
+func CSV() *Delimiter {
+	return separated(",")
+}
+
+// TSV returns a [Delimiter] splitting tab-separated values into fields like
+// [CSV], with tabs instead of commas.
+func TSV() *Delimiter {
+	return separated("\t")
+}
+
+func separated(sep string) *Delimiter {
+	d := &Delimiter{}
+	d.SetTokenRegexp(regexp.MustCompile(regexp.QuoteMeta(sep) + `|\r?\n`))
+	d.SetQuote('"')
+	d.SetJoiner(sep)
+	return d
+}
+
+// Paragraphs returns a [Delimiter] splitting the input into paragraphs, separated
+// by one or more blank lines, and joined back with a single blank line. Leading blank
+// lines are skipped. There is no stop pattern.
+func Paragraphs() *Delimiter {
+	d := &Delimiter{}
+	d.SetTokenRegexp(regexp.MustCompile(`\r?\n(?:[ \t]*\r?\n)+`))
+	d.SetLeading(EmptySkip)
+	d.SetJoiner("\n\n")
+	return d
+}
+
+// Bytes returns a [Delimiter] emitting every byte of the input as its own token,
+// for binary-ish protocols where each byte is inspected on its own.
+//
+// Stop patterns are not supported in this mode. Setting a token pattern on the
+// returned [Delimiter] switches it back to pattern based splitting.
+func Bytes() *Delimiter {
+	return core.NewSplitDelimiter(bufio.ScanBytes)
+}
+
+// Join concatenates tokens into a single string separated according to d, making
+// emitting data symmetric with parsing.
+//
+// Tokens are separated by the joiner of d if set with [Delimiter.SetJoiner], otherwise by
+// the string form of its token delimiter. Delimiters without a string form (regular
+// expressions, rune classes) fall back to "\n", and [Bytes] to no separator.
+// A nil d is the [Default] delimiter.
+//
+// When d has a quote (see [Delimiter.SetQuote]), tokens containing the separator, a
+// delimiter, the quote or a line break are enclosed in the quote, their quotes doubled,
+// so that Join([]string{"San Francisco, CA", "US"}, CSV()) gives
+// "\"San Francisco, CA\",US", read back as the same tokens once unquoted.
+//
+// Without a quote, tokens are written as is: d splits the result back into the same
+// tokens only if none of them contains the separator or a delimiter of d. For example,
+// Join([]string{"a\nb", "c"}, nil) gives "a\nb\nc", read back as three tokens.
+func Join(tokens []string, d *Delimiter) string {
+	if d == nil {
+		d = Default()
+	}
+	if core.Quote(d) != nil {
+		quoted := make([]string, len(tokens))
+		for i, token := range tokens {
+			quoted[i] = core.QuoteToken(d, token)
+		}
+		tokens = quoted
+	}
+	return strings.Join(tokens, core.JoinSep(d))
+}
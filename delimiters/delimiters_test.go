@@ -0,0 +1,68 @@
+package delimiters
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+)
+
+func split(d *Delimiter, input string) []string {
+	scanner := bufio.NewScanner(strings.NewReader(input))
+	scanner.Split(d.SplitFunc())
+	var tokens []string
+	for scanner.Scan() {
+		tokens = append(tokens, scanner.Text())
+	}
+	return tokens
+}
+
+func TestPresets(t *testing.T) {
+	for _, tc := range []struct {
+		d     *Delimiter
+		input string
+		want  []string
+	}{
+		{Lines(), "a\nb\n\nc", []string{"a", "b", "", "c"}},
+		{Words(), "  a b\tc ", []string{"a", "b", "c"}},
+		{Fields(" "), "a   b c", []string{"a", "b", "c"}},
+		{CSV(), "\"x, y\",z\n1,2", []string{"\"x, y\"", "z", "1", "2"}},
+		{Null(), "a\nb\x00c", []string{"a\nb", "c"}},
+		{Bytes(), "ab", []string{"a", "b"}},
+	} {
+		if got := split(tc.d, tc.input); strings.Join(got, "|") != strings.Join(tc.want, "|") {
+			t.Errorf("split(%q) = %q, want %q", tc.input, got, tc.want)
+		}
+	}
+}
+
+func TestRegister(t *testing.T) {
+	d := Lines().WithTokenStr(";")
+	Register("semicolons", d)
+	d.SetTokenStr(",")
+	got, ok := ByName("semicolons")
+	if !ok {
+		t.Fatal("ByName(\"semicolons\") not found")
+	}
+	got.SetTokenStr("|")
+	again, _ := ByName("semicolons")
+	if s := Join([]string{"a", "b"}, again); s != "a;b" {
+		t.Errorf("registered preset modified, Join() = %q, want %q", s, "a;b")
+	}
+	if _, ok := ByName("unknown"); ok {
+		t.Error("ByName(\"unknown\") found")
+	}
+}
+
+func TestJoin(t *testing.T) {
+	tokens := []string{"San Francisco, CA", "US"}
+	s := Join(tokens, CSV())
+	if s != "\"San Francisco, CA\",US" {
+		t.Fatalf("Join() = %q", s)
+	}
+	if got := split(CSV(), s); len(got) != 2 || got[1] != "US" {
+		t.Errorf("split(Join()) = %q", got)
+	}
+	if s := Join([]string{"a", "b"}, nil); s != "a\nb" {
+		t.Errorf("Join(nil delimiter) = %q, want %q", s, "a\nb")
+	}
+}
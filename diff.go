@@ -0,0 +1,119 @@
+package textio
+
+// EditOp is the operation of an [Edit].
+type EditOp int
+
+const (
+	// EditEqual keeps a token present in both streams.
+	EditEqual EditOp = iota
+	// EditInsert adds a token only present in the second stream.
+	EditInsert
+	// EditDelete removes a token only present in the first stream.
+	EditDelete
+)
+
+// String returns the name of the operation.
+func (op EditOp) String() string {
+	switch op {
+	case EditEqual:
+		return "equal"
+	case EditInsert:
+		return "insert"
+	case EditDelete:
+		return "delete"
+	}
+	return "unknown"
+}
+
+// Edit is a step of the edit script returned by [Diff].
+type Edit struct {
+	Op   EditOp
+	Text string
+}
+
+// Diff computes a shortest edit script turning the tokens of a into the tokens of b,
+// for example to show a word-level diff of two documents tokenized through the same
+// pipeline. Applying the [EditEqual] and [EditInsert] edits in order gives back b,
+// and the [EditEqual] and [EditDelete] edits give back a.
+//
+// Both sources are read entirely. If one of them fails, Diff returns its error.
+func Diff(a, b TokenSource) ([]Edit, error) {
+	as, err := readAll(a)
+	if err != nil {
+		return nil, err
+	}
+	bs, err := readAll(b)
+	if err != nil {
+		return nil, err
+	}
+	return diffTokens(as, bs), nil
+}
+
+// diffTokens implements [Diff] with the Myers O(ND) algorithm.
+// The trace keeps the 2d+1 diagonals reachable at each step d, which takes
+// O(D²) memory for an edit script of length D.
+func diffTokens(a, b []string) []Edit {
+	n, m := len(a), len(b)
+	// v[off+k] is the furthest x reached on diagonal k = x - y.
+	off := n + m + 1
+	v := make([]int, 2*off+1)
+	// trace[d][d+k] is v[off+k] before step d, for k in [-d, d].
+	var trace [][]int
+	for d := 0; d <= n+m; d++ {
+		trace = append(trace, append([]int(nil), v[off-d:off+d+1]...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[off+k-1] < v[off+k+1]) {
+				x = v[off+k+1]
+			} else {
+				x = v[off+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[off+k] = x
+			if x >= n && y >= m {
+				return backtrack(trace, a, b)
+			}
+		}
+	}
+	return nil
+}
+
+// backtrack walks the trace of [diffTokens] back from the end of both inputs.
+func backtrack(trace [][]int, a, b []string) []Edit {
+	x, y := len(a), len(b)
+	var edits []Edit
+	for d := len(trace) - 1; d > 0; d-- {
+		v := trace[d]
+		k := x - y
+		prevK := k - 1
+		if k == -d || (k != d && v[d+k-1] < v[d+k+1]) {
+			prevK = k + 1
+		}
+		prevX := v[d+prevK]
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			x--
+			y--
+			edits = append(edits, Edit{Op: EditEqual, Text: a[x]})
+		}
+		if x == prevX {
+			y--
+			edits = append(edits, Edit{Op: EditInsert, Text: b[y]})
+		} else {
+			x--
+			edits = append(edits, Edit{Op: EditDelete, Text: a[x]})
+		}
+	}
+	for x > 0 {
+		x--
+		edits = append(edits, Edit{Op: EditEqual, Text: a[x]})
+	}
+	for i, j := 0, len(edits)-1; i < j; i, j = i+1, j-1 {
+		edits[i], edits[j] = edits[j], edits[i]
+	}
+	return edits
+}
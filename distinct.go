@@ -0,0 +1,75 @@
+package textio
+
+import (
+	"hash/maphash"
+	"io"
+	"math"
+	"math/bits"
+)
+
+// hllPrecision is the number of index bits of the HyperLogLog sketch of
+// [Reader.EstimateDistinct], giving 2^14 registers and a standard error of 0.8%.
+const hllPrecision = 14
+
+// EstimateDistinct returns an estimate of the number of distinct accepted tokens,
+// computed in one pass with a HyperLogLog sketch, so the cardinality of huge inputs
+// is estimated without storing the tokens. The sketch uses 16 KiB whatever the input,
+// and the estimate is typically within 1% of the exact count, small counts being
+// nearly exact.
+//
+// Errors are the same as [Reader.ReadTokens], in which case the estimate covers the
+// tokens read before the error.
+// The optional opts override the [Reader] configuration for this call only.
+func (r *Reader) EstimateDistinct(opts ...ReadOption) (uint64, error) {
+	h := newHyperLogLog(hllPrecision)
+	it := r.apply(opts).iter()
+	defer it.close()
+	for {
+		token, err := it.next()
+		if err == io.EOF {
+			return h.estimate(), nil
+		}
+		if err != nil {
+			return h.estimate(), err
+		}
+		h.add(token)
+	}
+}
+
+// hyperLogLog is a HyperLogLog cardinality sketch.
+type hyperLogLog struct {
+	seed maphash.Seed
+	p    uint8
+	regs []uint8
+}
+
+func newHyperLogLog(p uint8) *hyperLogLog {
+	return &hyperLogLog{seed: maphash.MakeSeed(), p: p, regs: make([]uint8, 1<<p)}
+}
+
+func (h *hyperLogLog) add(s string) {
+	x := maphash.String(h.seed, s)
+	i := x >> (64 - h.p)
+	// The sentinel bit bounds the rank when the remaining bits are all 0.
+	w := x<<h.p | 1<<(h.p-1)
+	if rank := uint8(bits.LeadingZeros64(w) + 1); rank > h.regs[i] {
+		h.regs[i] = rank
+	}
+}
+
+func (h *hyperLogLog) estimate() uint64 {
+	m := float64(len(h.regs))
+	sum, zeros := 0.0, 0
+	for _, r := range h.regs {
+		sum += math.Ldexp(1, -int(r))
+		if r == 0 {
+			zeros++
+		}
+	}
+	e := 0.7213 / (1 + 1.079/m) * m * m / sum
+	if e <= 2.5*m && zeros > 0 {
+		// Linear counting is more accurate for small cardinalities.
+		e = m * math.Log(m/float64(zeros))
+	}
+	return uint64(e + 0.5)
+}
@@ -1,103 +1,147 @@
 package textio
 
 import (
-	"errors"
 	"fmt"
-	"runtime"
-	"strings"
-)
 
-// type ReaderErrorKind int
+	"github.com/JFinlayM/textio/internal/core"
+)
 
 var (
-	ErrInvalid             = errors.New("textio: invalid token")
-	ErrRead                = errors.New("textio: read error")
-	ErrClose               = errors.New("textio: close error")
-	ErrOutputBufferBlocked = errors.New("textio: output buffer is blocked")
-	ErrOpen                = errors.New("textio: open error")
+	ErrInvalid             = core.ErrInvalid
+	ErrRead                = core.ErrRead
+	ErrClose               = core.ErrClose
+	ErrOutputBufferBlocked = core.ErrOutputBufferBlocked
+	ErrOpen                = core.ErrOpen
+	ErrMaxBytes            = core.ErrMaxBytes
+	ErrBinaryInput         = core.ErrBinaryInput
+	ErrLeadingDelimiter    = core.ErrLeadingDelimiter
+	ErrWrite               = core.ErrWrite
+	ErrLimit               = core.ErrLimit
+	ErrPatternTimeout      = core.ErrPatternTimeout
+	ErrLengthMismatch      = core.ErrLengthMismatch
+	ErrConfig              = core.ErrConfig
 )
 
-type ReaderError struct {
-	Kind error
-	Err  error
-	// Metadata
-	Token     string
-	Index     int
-	FileName  string
-	FuncName  string
-	ErrorLine int
-}
+type ReaderError = core.ReaderError
 
-type ReaderCloserError struct {
-	*ReaderError
-	Filepath string
+// BudgetExceededError is the error wrapped by [ErrMaxBytes] errors, naming the source
+// whose bytes pushed the input over the budget set with [Reader.SetMaxBytes].
+type BudgetExceededError struct {
+	// Source is the name of the source, empty if it has none (see [TokenInfo]).
+	Source string
+	// Limit is the byte budget.
+	Limit int64
 }
 
-func (e *ReaderError) Error() string {
-	if e.Err != nil {
-		return fmt.Sprintf("%v: %v", e.Kind, e.Err)
+func (e *BudgetExceededError) Error() string {
+	if e.Source == "" {
+		return fmt.Sprintf("more than %d bytes", e.Limit)
 	}
-	return e.Kind.Error()
-}
-
-func (e *ReaderError) Is(target error) bool {
-	return e.Kind == target
+	return fmt.Sprintf("more than %d bytes, exceeded by %s", e.Limit, e.Source)
 }
 
-func (e *ReaderError) Unwrap() error {
-	return e.Err
+func (e *BudgetExceededError) Is(target error) bool {
+	return target == ErrMaxBytes
 }
 
-func newReaderError(skip int) *ReaderError {
-	pc, file, line, _ := runtime.Caller(skip)
-
-	fileName := file
-	if i := strings.LastIndex(file, "/"); i >= 0 {
-		fileName = file[i+1:]
-	}
-
-	funcName := ""
-	if fn := runtime.FuncForPC(pc); fn != nil {
-		name := fn.Name()
-		if i := strings.LastIndex(name, "."); i >= 0 {
-			funcName = name[i+1:]
-		} else {
-			funcName = name
-		}
-	}
-
-	return &ReaderError{
-		FileName:  fileName,
-		FuncName:  funcName,
-		ErrorLine: line,
-		Index:     -1,
-	}
+type ReaderCloserError struct {
+	*ReaderError
+	Filepath string
 }
 
 func newReaderCloserError(skip int) *ReaderCloserError {
-	re := newReaderError(skip + 1)
+	re := core.NewReaderError(skip + 1)
 	return &ReaderCloserError{
 		ReaderError: re,
 	}
 }
 
 func newErrInvalid(token string, index int) error {
-	re := newReaderError(3)
+	re := core.NewReaderError(3)
+	re.Kind = ErrInvalid
+	re.Token = token
+	re.Index = index
+	return re
+}
+
+func newErrDecode(token string, index int, err error) error {
+	re := core.NewReaderError(3)
 	re.Kind = ErrInvalid
 	re.Token = token
 	re.Index = index
+	re.Err = err
 	return re
 }
 
 func newErrRead(err error) error {
-	re := newReaderError(3)
+	re := core.NewReaderError(3)
 	re.Kind = ErrRead
 	re.Err = err
 	return re
 }
 
+func newErrMaxBytes(err *BudgetExceededError) error {
+	re := core.NewReaderError(3)
+	re.Kind = ErrMaxBytes
+	re.Err = err
+	re.Source = err.Source
+	return re
+}
+
+func newErrLimit(limit int) error {
+	re := core.NewReaderError(3)
+	re.Kind = ErrLimit
+	re.Index = limit
+	re.Err = fmt.Errorf("more than %d tokens", limit)
+	return re
+}
+
+func newErrPatternTimeout(err error) error {
+	re := core.NewReaderError(3)
+	re.Kind = ErrPatternTimeout
+	re.Err = err
+	return re
+}
+
+func newErrLengthMismatch(index int) error {
+	re := core.NewReaderError(3)
+	re.Kind = ErrLengthMismatch
+	re.Index = index
+	return re
+}
+
+func newErrConfig(err error) error {
+	re := core.NewReaderError(3)
+	re.Kind = ErrConfig
+	re.Err = err
+	return re
+}
+
+func newErrBinaryInput(err error) error {
+	re := core.NewReaderError(3)
+	re.Kind = ErrBinaryInput
+	re.Err = err
+	return re
+}
+
+func newErrLeadingDelimiter() error {
+	re := core.NewReaderError(3)
+	re.Kind = ErrLeadingDelimiter
+	re.Index = 0
+	return re
+}
+
+func newErrWrite(token string, index int, err error) error {
+	re := core.NewReaderError(3)
+	re.Kind = ErrWrite
+	re.Token = token
+	re.Index = index
+	re.Err = err
+	return re
+}
+
 func newErrOutputBufferBlocked(token string, index int) error {
-	re := newReaderError(3)
+	re := core.NewReaderError(3)
 	re.Kind = ErrOutputBufferBlocked
 	re.Token = token
 	re.Index = index
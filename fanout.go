@@ -0,0 +1,172 @@
+package textio
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+)
+
+// SlowConsumerPolicy is what a fan-out helper such as [Broadcast] does
+// when the buffer of a consumer is full.
+type SlowConsumerPolicy int
+
+const (
+	// SlowBlock waits for the consumer, slowing down every consumer to the
+	// pace of the slowest one.
+	SlowBlock SlowConsumerPolicy = iota
+	// SlowDrop drops the token for the consumer whose buffer is full,
+	// so slow consumers do not hold back the others.
+	SlowDrop
+)
+
+// FanOutOption configures the fan-out helpers such as [Broadcast].
+type FanOutOption func(*fanOut)
+
+// WithBuffer returns a [FanOutOption] giving every output channel a buffer of n tokens.
+func WithBuffer(n int) FanOutOption {
+	return func(f *fanOut) {
+		f.buffer = n
+	}
+}
+
+// WithSlowConsumer returns a [FanOutOption] setting the policy applied to
+// consumers whose buffer is full. The default is [SlowBlock].
+func WithSlowConsumer(p SlowConsumerPolicy) FanOutOption {
+	return func(f *fanOut) {
+		f.policy = p
+	}
+}
+
+// fanOut sends the tokens of a source to several channels.
+type fanOut struct {
+	buffer int
+	policy SlowConsumerPolicy
+	outs   []chan string
+}
+
+func newFanOut(n int, opts []FanOutOption) *fanOut {
+	if n < 0 {
+		panic(fmt.Sprintf("textio: fan-out to %d channels", n))
+	}
+	f := &fanOut{}
+	for _, opt := range opts {
+		opt(f)
+	}
+	f.outs = make([]chan string, n)
+	for i := range f.outs {
+		f.outs[i] = make(chan string, f.buffer)
+	}
+	return f
+}
+
+// channels returns the output channels as receive-only channels.
+func (f *fanOut) channels() []<-chan string {
+	chans := make([]<-chan string, len(f.outs))
+	for i, ch := range f.outs {
+		chans[i] = ch
+	}
+	return chans
+}
+
+// run calls route with every token of src in a new goroutine, and closes the
+// output channels once src is exhausted or ctx is done.
+func (f *fanOut) run(ctx context.Context, src TokenSource, route func(token string) bool) {
+	go func() {
+		defer func() {
+			closeSource(src)
+			for _, ch := range f.outs {
+				close(ch)
+			}
+		}()
+		for src.Scan() {
+			if !route(src.Text()) {
+				return
+			}
+		}
+	}()
+}
+
+// send sends token to the output i according to the policy of f.
+// It returns false if ctx is done.
+func (f *fanOut) send(ctx context.Context, i int, token string) bool {
+	if f.policy == SlowDrop {
+		select {
+		case f.outs[i] <- token:
+		case <-ctx.Done():
+			return false
+		default:
+		}
+		return true
+	}
+	select {
+	case f.outs[i] <- token:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// Broadcast sends every token of src to n channels, so one read pass can serve
+// several consumers. The channels are unbuffered and every consumer is waited for,
+// unless configured otherwise with [WithBuffer] and [WithSlowConsumer].
+//
+// The channels are closed once src is exhausted or ctx is done. src must not be
+// used until then; its Err method then tells whether it failed.
+// Broadcast panics if n is negative.
+func Broadcast(ctx context.Context, src TokenSource, n int, opts ...FanOutOption) []<-chan string {
+	f := newFanOut(n, opts)
+	f.run(ctx, src, func(token string) bool {
+		for i := range f.outs {
+			if !f.send(ctx, i, token) {
+				return false
+			}
+		}
+		return true
+	})
+	return f.channels()
+}
+
+// Shard sends every token of src to one of n channels, chosen by key(token) modulo n,
+// so that per-key aggregation can run in parallel without locking: equal keys
+// always go to the same channel. A nil key hashes the token with FNV-1a.
+//
+// Channels, options and the end of the stream are handled as with [Broadcast].
+// Shard panics if n is less than 1, as there would be no channel for the tokens.
+func Shard(ctx context.Context, src TokenSource, n int, key func(string) uint64, opts ...FanOutOption) []<-chan string {
+	if n < 1 {
+		panic(fmt.Sprintf("textio: Shard to %d channels", n))
+	}
+	if key == nil {
+		key = hashFNV
+	}
+	f := newFanOut(n, opts)
+	f.run(ctx, src, func(token string) bool {
+		return f.send(ctx, int(key(token)%uint64(n)), token)
+	})
+	return f.channels()
+}
+
+// hashFNV returns the 64-bit FNV-1a hash of s.
+func hashFNV(s string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(s))
+	return h.Sum64()
+}
+
+// Partition sends the tokens of src satisfying f to accepted and the others to rejected,
+// so valid and invalid tokens can be routed to different sinks in one pass.
+// A nil f accepts every token. Both channels must be consumed, unless the [SlowDrop] policy is used.
+// See [PartitionTo] for the [TokenWriter] based variant.
+//
+// Channels, options and the end of the stream are handled as with [Broadcast].
+func Partition(ctx context.Context, src TokenSource, f FilterFunc, opts ...FanOutOption) (accepted, rejected <-chan string) {
+	fo := newFanOut(2, opts)
+	fo.run(ctx, src, func(token string) bool {
+		if f.accepts(token) {
+			return fo.send(ctx, 0, token)
+		}
+		return fo.send(ctx, 1, token)
+	})
+	chans := fo.channels()
+	return chans[0], chans[1]
+}
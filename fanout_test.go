@@ -0,0 +1,162 @@
+package textio
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func collect(chans []<-chan string) [][]string {
+	got := make([][]string, len(chans))
+	var wg sync.WaitGroup
+	for i, ch := range chans {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for tok := range ch {
+				got[i] = append(got[i], tok)
+			}
+		}()
+	}
+	wg.Wait()
+	return got
+}
+
+func TestBroadcast(t *testing.T) {
+	src := NewReader().FromString("a\nb\nc").Source()
+	got := collect(Broadcast(context.Background(), src, 3))
+
+	for i, tokens := range got {
+		if strings.Join(tokens, "|") != "a|b|c" {
+			t.Errorf("consumer %d got %q, want %q", i, tokens, []string{"a", "b", "c"})
+		}
+	}
+	if err := src.Err(); err != nil {
+		t.Errorf("Err() = %v", err)
+	}
+}
+
+func TestBroadcast_SlowDrop(t *testing.T) {
+	in := make(chan string)
+	chans := Broadcast(context.Background(), FromChannel(in), 2, WithBuffer(2), WithSlowConsumer(SlowDrop))
+
+	// The first consumer reads every token before the next one is sent,
+	// while the second one only reads at the end.
+	var fast []string
+	for i := 1; i <= 8; i++ {
+		tok := strconv.Itoa(i)
+		in <- tok
+		select {
+		case got := <-chans[0]:
+			fast = append(fast, got)
+		case <-time.After(time.Second):
+			t.Fatalf("fast consumer did not get token %s", tok)
+		}
+	}
+	close(in)
+	for tok := range chans[0] {
+		fast = append(fast, tok)
+	}
+	var slow []string
+	for tok := range chans[1] {
+		slow = append(slow, tok)
+	}
+
+	if strings.Join(fast, " ") != "1 2 3 4 5 6 7 8" {
+		t.Errorf("fast consumer got %q, want every token", fast)
+	}
+	if strings.Join(slow, " ") != "1 2" {
+		t.Errorf("slow consumer got %q, want the 2 tokens fitting its buffer", slow)
+	}
+}
+
+func TestBroadcast_Cancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	tokens := make([]string, 1000)
+	chans := Broadcast(ctx, FromTokens(tokens), 2)
+	<-chans[0]
+	cancel()
+
+	// The second consumer never reads: without cancellation, the first
+	// channel would never be closed.
+	deadline := time.After(time.Second)
+	for open := true; open; {
+		select {
+		case _, open = <-chans[0]:
+		case <-deadline:
+			t.Fatal("channels not closed after cancel")
+		}
+	}
+	select {
+	case _, ok := <-chans[1]:
+		if ok {
+			t.Error("got a token after cancel, want a closed channel")
+		}
+	case <-deadline:
+		t.Fatal("channels not closed after cancel")
+	}
+}
+
+func TestShard(t *testing.T) {
+	words := strings.Fields("a b c a d b a e f c")
+	got := collect(Shard(context.Background(), FromTokens(words), 3, nil, WithBuffer(4)))
+
+	seen := map[string]int{}
+	total := 0
+	for i, tokens := range got {
+		for _, tok := range tokens {
+			if shard, ok := seen[tok]; ok && shard != i {
+				t.Errorf("token %q in shards %d and %d", tok, shard, i)
+			}
+			seen[tok] = i
+			total++
+		}
+	}
+	if total != len(words) {
+		t.Errorf("got %d tokens, want %d", total, len(words))
+	}
+
+	byLen := func(s string) uint64 { return uint64(len(s)) }
+	got = collect(Shard(context.Background(), FromTokens([]string{"x", "yy", "zzz", "w"}), 2, byLen))
+	if strings.Join(got[0], "|") != "yy" || strings.Join(got[1], "|") != "x|zzz|w" {
+		t.Errorf("Shard() = %q", got)
+	}
+
+	for _, n := range []int{0, -1} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Shard() to %d channels did not panic", n)
+				}
+			}()
+			Shard(context.Background(), FromTokens(words), n, nil)
+		}()
+	}
+}
+
+func TestPartition(t *testing.T) {
+	src := NewReader().FromString("42\nabc\n7\n\n-1").Source()
+	isNumber := func(s string) bool {
+		_, err := strconv.Atoi(s)
+		return err == nil
+	}
+	accepted, rejected := Partition(context.Background(), src, isNumber)
+	got := collect([]<-chan string{accepted, rejected})
+
+	if strings.Join(got[0], "|") != "42|7|-1" {
+		t.Errorf("accepted = %q", got[0])
+	}
+	if strings.Join(got[1], "|") != "abc|" {
+		t.Errorf("rejected = %q", got[1])
+	}
+
+	src = NewReader().FromString("a\nb").Source()
+	accepted, rejected = Partition(context.Background(), src, nil)
+	got = collect([]<-chan string{accepted, rejected})
+	if strings.Join(got[0], "|") != "a|b" || len(got[1]) != 0 {
+		t.Errorf("nil filter: accepted = %q, rejected = %q", got[0], got[1])
+	}
+}
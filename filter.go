@@ -2,10 +2,11 @@ package textio
 
 import (
 	"regexp"
-	"strings"
+
+	"github.com/JFinlayM/textio/filters"
 )
 
-// s is the string currently being read parameter is set as the [UserContext] attribute.
+// s is the token currently being read, after normalization.
 // Should return true is the token satisfies user defined constraints, false otherwise.
 type FilterFunc func(s string) bool
 
@@ -14,23 +15,19 @@ type FilterFunc func(s string) bool
 // The input string is trimmed using strings.TrimSpace before evaluation.
 // If the resulting string is empty, the token is rejected.
 func FilterNonEmpty(s string) FilterFunc {
-	return func(s string) bool { return strings.TrimSpace(s) != "" }
+	return filters.NonEmpty()
 }
 
 // FilterMinLength returns a FilterFunc that accepts only strings
 // whose length is greater than or equal to n.
 func FilterMinLength(n int) FilterFunc {
-	return func(s string) bool {
-		return len(s) >= n
-	}
+	return filters.MinLength(n)
 }
 
 // FilterMaxLength returns a FilterFunc that accepts only strings
 // whose length is less than or equal to n.
 func FilterMaxLength(n int) FilterFunc {
-	return func(s string) bool {
-		return len(s) <= n
-	}
+	return filters.MaxLength(n)
 }
 
 // FilterRegexp returns a FilterFunc that accepts strings
@@ -38,37 +35,97 @@ func FilterMaxLength(n int) FilterFunc {
 //
 // The caller is responsible for compiling the regexp.
 func FilterRegexp(re *regexp.Regexp) FilterFunc {
-	return func(s string) bool {
-		return re.MatchString(s)
-	}
+	return filters.Regexp(re)
 }
 
 // And combines two FilterFunc using a logical AND.
 //
 // The resulting filter accepts a string only if both filters
-// accept it.
+// accept it. A nil filter accepts every string, as with [Reader.SetFilter].
 func (f1 FilterFunc) And(f2 FilterFunc) FilterFunc {
 	return func(s string) bool {
-		return f1(s) && f2(s)
+		return f1.accepts(s) && f2.accepts(s)
 	}
 }
 
 // Or combines two FilterFunc using a logical OR.
 //
 // The resulting filter accepts a string if at least one
-// of the filters accepts it.
+// of the filters accepts it. A nil filter accepts every string.
 func (f1 FilterFunc) Or(f2 FilterFunc) FilterFunc {
 	return func(s string) bool {
-		return f1(s) || f2(s)
+		return f1.accepts(s) || f2.accepts(s)
 	}
 }
 
 // Not returns a FilterFunc that negates the result of the given filter.
 //
 // The resulting filter accepts a string if and only if
-// the original filter rejects it.
+// the original filter rejects it. A nil filter accepts every string,
+// so its negation rejects them all.
 func Not(f FilterFunc) FilterFunc {
-	return func(s string) bool {
-		return !f(s)
+	return filters.Not(f)
+}
+
+// accepts reports whether f accepts s, a nil f accepting every string.
+func (f FilterFunc) accepts(s string) bool {
+	return f == nil || f(s)
+}
+
+// FilterFuncInfo is a variant of [FilterFunc] receiving the [TokenInfo] of the
+// token currently being read, which allows position-aware validation.
+// Should return true is the token satisfies user defined constraints, false otherwise.
+type FilterFuncInfo func(info TokenInfo) bool
+
+// FilterIndexedFunc is a variant of [FilterFunc] also receiving the index of the token
+// among the tokens read (see [TokenInfo]), for rules such as skipping the first token
+// without keeping a counter.
+type FilterIndexedFunc func(s string, index int) bool
+
+// SetIndexedFilter sets the function to be called with the index of current read token
+// to filter it, for example r.SetIndexedFilter(func(_ string, i int) bool { return i%2 == 1 })
+// to keep the odd-numbered tokens. The index does not depend on the tokens rejected
+// before, so the filter keeps no state and the same token gets the same index on every read.
+//
+// It is a shorthand for [Reader.SetFilterInfo], and resets the function set with
+// [Reader.SetFilter].
+func (r *Reader) SetIndexedFilter(f FilterIndexedFunc) {
+	if f == nil {
+		r.SetFilterInfo(nil)
+		return
+	}
+	r.SetFilterInfo(func(info TokenInfo) bool {
+		return f(info.Text, info.Index)
+	})
+}
+
+// StatefulFilter is a filter keeping state across the tokens it sees, such as the
+// tokens already seen (see [filters.Dedup]) or the number of tokens accepted so far.
+//
+// Accept reports whether the token s satisfies the filter, like a [FilterFunc],
+// and Reset restores the state the filter had before seeing any token.
+type StatefulFilter interface {
+	Accept(s string) bool
+	Reset()
+}
+
+// SetStatefulFilter sets f as the filter of the [Reader], like [Reader.SetFilter]
+// with f.Accept, and resets f. f is reset again whenever the [Reader] is given new
+// input with [Reader.SetReaders] (which the FromXxx methods use) or [Reader.SetSource],
+// so that a [Reader] reused on a new source does not carry the state of the previous one.
+//
+// The copies of the [Reader] share f, so that resetting one of them resets f for all.
+// Reads covering several parts of one input, such as [Reader.ReadFilesParallel] or
+// the shards of [ReaderCloser.FromFileRange], do not reset f, so that f sees the whole input.
+func (r *Reader) SetStatefulFilter(f StatefulFilter) {
+	r.SetFilter(f.Accept)
+	r.stateful = f
+	f.Reset()
+}
+
+// resetFilter resets the filter set with [Reader.SetStatefulFilter], if any.
+func (r *Reader) resetFilter() {
+	if r.stateful != nil {
+		r.stateful.Reset()
 	}
 }
@@ -0,0 +1,63 @@
+package filters
+
+import (
+	"hash/maphash"
+	"math"
+	"sync"
+)
+
+// NotSeenBloom returns a filter accepting the first occurrence of each token
+// and rejecting the tokens already seen, using a Bloom filter sized for expectedN distinct
+// tokens with a false positive rate of fpRate, such as 0.001.
+//
+// Memory is bounded whatever the length of the stream, about 1.2 bytes per expected
+// token at a 1% rate, at the cost of rejecting a fraction fpRate of the new tokens
+// as if they were seen before; seen tokens are always rejected. The rate grows when
+// more than expectedN distinct tokens are read.
+//
+// The returned filter keeps its state across reads, and is safe for concurrent use.
+func NotSeenBloom(expectedN int, fpRate float64) func(s string) bool {
+	b := newBloom(max(expectedN, 1), min(max(fpRate, 1e-12), 0.5))
+	return func(s string) bool {
+		return b.add(s)
+	}
+}
+
+// bloom is a Bloom filter using double hashing.
+type bloom struct {
+	mu     sync.Mutex
+	s1, s2 maphash.Seed
+	bits   []uint64
+	m      uint64
+	k      int
+}
+
+func newBloom(n int, p float64) *bloom {
+	m := uint64(math.Ceil(-float64(n) * math.Log(p) / (math.Ln2 * math.Ln2)))
+	k := max(int(math.Round(float64(m)/float64(n)*math.Ln2)), 1)
+	return &bloom{
+		s1:   maphash.MakeSeed(),
+		s2:   maphash.MakeSeed(),
+		bits: make([]uint64, (m+63)/64),
+		m:    m,
+		k:    k,
+	}
+}
+
+// add adds s to b, and reports whether s was not in b.
+func (b *bloom) add(s string) bool {
+	h1 := maphash.String(b.s1, s)
+	h2 := maphash.String(b.s2, s) | 1
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	added := false
+	for i := range b.k {
+		bit := (h1 + uint64(i)*h2) % b.m
+		word, mask := bit/64, uint64(1)<<(bit%64)
+		if b.bits[word]&mask == 0 {
+			b.bits[word] |= mask
+			added = true
+		}
+	}
+	return added
+}
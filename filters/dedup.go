@@ -0,0 +1,58 @@
+package filters
+
+import (
+	"maps"
+	"strings"
+	"sync"
+)
+
+// Dedup is a filter accepting the first occurrence of each token and rejecting the
+// tokens already seen, keeping every distinct token in memory. See [NotSeenBloom]
+// for a filter using bounded memory.
+//
+// A Dedup is a stateful filter that package textio resets for each new source,
+// see github.com/JFinlayM/textio.Reader.SetStatefulFilter. It is safe for concurrent use, so that batch and streaming reads, or
+// concurrent reads, share the same state.
+type Dedup struct {
+	mu         sync.Mutex
+	seen       map[string]struct{}
+	ignoreCase bool
+}
+
+// NewDedup returns a [Dedup] that has seen no token yet. If ignoreCase is set, tokens
+// differing only in case are the same token, see [strings.ToLower].
+func NewDedup(ignoreCase bool) *Dedup {
+	return &Dedup{
+		seen:       make(map[string]struct{}),
+		ignoreCase: ignoreCase,
+	}
+}
+
+// Accept reports whether s was not seen before, and records it as seen.
+func (d *Dedup) Accept(s string) bool {
+	if d.ignoreCase {
+		s = strings.ToLower(s)
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if _, ok := d.seen[s]; ok {
+		return false
+	}
+	// The token may share the memory of other tokens, see the low-alloc mode of package textio.
+	d.seen[strings.Clone(s)] = struct{}{}
+	return true
+}
+
+// Clone returns a new [Dedup] with the settings of d, having seen the same tokens.
+func (d *Dedup) Clone() *Dedup {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return &Dedup{seen: maps.Clone(d.seen), ignoreCase: d.ignoreCase}
+}
+
+// Reset forgets the tokens seen so far.
+func (d *Dedup) Reset() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	clear(d.seen)
+}
@@ -0,0 +1,91 @@
+// Package filters is the library of token filters, for programs that only need to
+// filter tokens. The filters are plain func(string) bool values, which can be given
+// as such to the Reader.SetFilter method of package [github.com/JFinlayM/textio]
+// and combined with its FilterFunc.And method.
+//
+// Package filters does not depend on package textio, so the library can grow
+// without bloating the core. Package textio re-exports the basic filters, such as
+// MinLength and Regexp, as its FilterXxx functions.
+package filters
+
+import (
+	"regexp"
+	"strings"
+)
+
+// NonEmpty returns a filter rejecting empty or whitespace-only tokens.
+//
+// The token is trimmed using strings.TrimSpace before evaluation.
+func NonEmpty() func(s string) bool {
+	return func(s string) bool { return strings.TrimSpace(s) != "" }
+}
+
+// MinLength returns a filter accepting the tokens of at least n bytes.
+func MinLength(n int) func(s string) bool {
+	return func(s string) bool {
+		return len(s) >= n
+	}
+}
+
+// MaxLength returns a filter accepting the tokens of at most n bytes.
+func MaxLength(n int) func(s string) bool {
+	return func(s string) bool {
+		return len(s) <= n
+	}
+}
+
+// Regexp returns a filter accepting the tokens matching re.
+//
+// The caller is responsible for compiling the regexp.
+func Regexp(re *regexp.Regexp) func(s string) bool {
+	return func(s string) bool {
+		return re.MatchString(s)
+	}
+}
+
+// RegexpLimit is like [Regexp] but rejects the tokens longer
+// than maxLen bytes without matching them against re.
+func RegexpLimit(re *regexp.Regexp, maxLen int) func(s string) bool {
+	return func(s string) bool {
+		return len(s) <= maxLen && re.MatchString(s)
+	}
+}
+
+// Not returns a filter accepting a token if and only if f rejects it.
+// A nil filter accepts every token, so its negation rejects them all.
+func Not(f func(s string) bool) func(s string) bool {
+	return func(s string) bool {
+		return !accepts(f, s)
+	}
+}
+
+// All returns a filter accepting the tokens accepted by every filter of fs.
+// A nil filter accepts every token, and so does All with no filter.
+func All(fs ...func(s string) bool) func(s string) bool {
+	return func(s string) bool {
+		for _, f := range fs {
+			if !accepts(f, s) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Any returns a filter accepting the tokens accepted by at least one filter of fs.
+// A nil filter accepts every token. With no filter, every token is rejected.
+func Any(fs ...func(s string) bool) func(s string) bool {
+	return func(s string) bool {
+		for _, f := range fs {
+			if accepts(f, s) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// accepts reports whether f accepts s, a nil f accepting every string.
+func accepts(f func(s string) bool, s string) bool {
+	return f == nil || f(s)
+}
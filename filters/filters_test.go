@@ -0,0 +1,45 @@
+package filters
+
+import "testing"
+
+func TestAllAny(t *testing.T) {
+	all := All(MinLength(2), MaxLength(3))
+	anyOf := Any(MaxLength(1), MinLength(4))
+	for _, tc := range []struct {
+		token    string
+		all, any bool
+	}{
+		{"a", false, true},
+		{"ab", true, false},
+		{"abcd", false, true},
+	} {
+		if got := all(tc.token); got != tc.all {
+			t.Errorf("All(%q) = %v, want %v", tc.token, got, tc.all)
+		}
+		if got := anyOf(tc.token); got != tc.any {
+			t.Errorf("Any(%q) = %v, want %v", tc.token, got, tc.any)
+		}
+	}
+	if !All()("x") || Any()("x") {
+		t.Error("All() must accept and Any() must reject every token")
+	}
+	if !All(nil, NonEmpty())("x") || !Any(nil)("") || Not(nil)("x") {
+		t.Error("a nil filter must accept every token")
+	}
+}
+
+func TestStopwords(t *testing.T) {
+	f, ok := Stopwords("en")
+	if !ok {
+		t.Fatal("Stopwords(en) not found")
+	}
+	if f("The") || !f("river") {
+		t.Errorf("Stopwords(en) must reject %q and accept %q", "The", "river")
+	}
+	if _, ok := Stopwords("no-such-lang"); ok {
+		t.Error("Stopwords() found an unregistered language")
+	}
+	if NotIn("a", "b")("a") || !NotIn("a", "b")("c") {
+		t.Error("NotIn(a, b) must reject a and accept c")
+	}
+}
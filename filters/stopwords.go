@@ -0,0 +1,70 @@
+package filters
+
+import (
+	"embed"
+	"path"
+	"strings"
+	"sync"
+)
+
+//go:embed stopwords/*.txt
+var stopwordFiles embed.FS
+
+var (
+	stopwordsMu sync.RWMutex
+	stopwords   = map[string]map[string]struct{}{}
+)
+
+func init() {
+	files, _ := stopwordFiles.ReadDir("stopwords")
+	for _, f := range files {
+		data, _ := stopwordFiles.ReadFile(path.Join("stopwords", f.Name()))
+		RegisterStopwords(strings.TrimSuffix(f.Name(), ".txt"), strings.Fields(string(data))...)
+	}
+}
+
+// NotIn returns a filter rejecting the tokens equal to one of words.
+func NotIn(words ...string) func(s string) bool {
+	set := make(map[string]struct{}, len(words))
+	for _, w := range words {
+		set[w] = struct{}{}
+	}
+	return func(s string) bool {
+		_, ok := set[s]
+		return !ok
+	}
+}
+
+// Stopwords returns a filter rejecting the stop words of the language lang,
+// such as "the", "and" or "of" in English, whatever their case (see [strings.ToLower]).
+//
+// Word lists are built in for English ("en"), French ("fr"), German ("de") and
+// Spanish ("es"), and others can be added with [RegisterStopwords].
+// ok is false if there is no list for lang.
+func Stopwords(lang string) (f func(s string) bool, ok bool) {
+	stopwordsMu.RLock()
+	set, ok := stopwords[lang]
+	stopwordsMu.RUnlock()
+	if !ok {
+		return nil, false
+	}
+	return func(s string) bool {
+		_, ok := set[strings.ToLower(s)]
+		return !ok
+	}, true
+}
+
+// RegisterStopwords adds words to the stop words of the language lang, used by the
+// filters later returned by [Stopwords]. Words are matched in lower case.
+func RegisterStopwords(lang string, words ...string) {
+	stopwordsMu.Lock()
+	defer stopwordsMu.Unlock()
+	set := make(map[string]struct{}, len(stopwords[lang])+len(words))
+	for w := range stopwords[lang] {
+		set[w] = struct{}{}
+	}
+	for _, w := range words {
+		set[strings.ToLower(w)] = struct{}{}
+	}
+	stopwords[lang] = set
+}
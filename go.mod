@@ -1,3 +1,8 @@
 module github.com/JFinlayM/textio
 
-go 1.22.2
+go 1.23
+
+require (
+	github.com/klauspost/compress v1.17.11
+	golang.org/x/text v0.21.0
+)
@@ -0,0 +1,76 @@
+package textio
+
+import (
+	"context"
+	"io"
+)
+
+// Group is a run of tokens sharing the same key, see [Reader.StreamGroups].
+type Group struct {
+	Key    string
+	Tokens []string
+}
+
+// GroupTokens reads the tokens of r and buckets them by key(token), for example to
+// group lines by prefix or date. The tokens of each group keep their order.
+//
+// On error, the groups built before the failure are returned along with the error.
+func (r *Reader) GroupTokens(key func(string) string, opts ...ReadOption) (map[string][]string, error) {
+	groups := make(map[string][]string)
+	it := r.apply(opts).iter()
+	defer it.close()
+	for {
+		token, err := it.next()
+		if err == io.EOF {
+			return groups, nil
+		}
+		if err != nil {
+			return groups, err
+		}
+		k := key(token)
+		groups[k] = append(groups[k], token)
+	}
+}
+
+// StreamGroups is the streaming variant of [Reader.GroupTokens] for input sorted by key:
+// consecutive tokens with the same key are gathered, and each group is sent to out as
+// soon as a token with another key, or the end of the input, completes it.
+// On unsorted input, a key may therefore be sent several times.
+//
+// Like [Reader.StreamTokens], it never closes out and returns ctx.Err() if ctx is done.
+// On error, the group in progress is not sent.
+func (r *Reader) StreamGroups(ctx context.Context, key func(string) string, out chan<- Group, opts ...ReadOption) error {
+	var cur *Group
+	send := func() error {
+		if cur == nil {
+			return nil
+		}
+		select {
+		case out <- *cur:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	it := r.apply(opts).iter()
+	defer it.close()
+	for {
+		token, err := it.next()
+		if err == io.EOF {
+			return send()
+		}
+		if err != nil {
+			return err
+		}
+		k := key(token)
+		if cur != nil && cur.Key == k {
+			cur.Tokens = append(cur.Tokens, token)
+			continue
+		}
+		if err := send(); err != nil {
+			return err
+		}
+		cur = &Group{Key: k, Tokens: []string{token}}
+	}
+}
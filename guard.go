@@ -0,0 +1,38 @@
+package textio
+
+import (
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+)
+
+// maxPatternInsts is the size of the compiled program above which
+// [VetPattern] rejects a regular expression.
+const maxPatternInsts = 10000
+
+// VetPattern reports whether the regular expression expr is safe to use as a
+// user-supplied delimiter or filter. Go regular expressions run in linear time,
+// but some patterns are still harmful:
+//   - patterns matching the empty string, which split the input at every byte
+//     and never make progress as delimiters;
+//   - patterns whose compiled program is huge, such as nested counted
+//     repetitions like `(a{100}){100}`, which are slow to compile and to run.
+//
+// It returns nil if expr is acceptable, or an error describing the problem.
+func VetPattern(expr string) error {
+	re, err := syntax.Parse(expr, syntax.Perl)
+	if err != nil {
+		return fmt.Errorf("textio: invalid pattern: %w", err)
+	}
+	prog, err := syntax.Compile(re.Simplify())
+	if err != nil {
+		return fmt.Errorf("textio: invalid pattern: %w", err)
+	}
+	if len(prog.Inst) > maxPatternInsts {
+		return fmt.Errorf("textio: pattern %q is too complex (%d instructions)", expr, len(prog.Inst))
+	}
+	if regexp.MustCompile(expr).MatchString("") {
+		return fmt.Errorf("textio: pattern %q matches the empty string", expr)
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package textio
+
+import (
+	"context"
+
+	"github.com/JFinlayM/textio/writers"
+)
+
+// TokenReader defines the minimal contract for reading tokens
+// from an input source in a batch-oriented manner.
+//
+// Implementations return all available tokens at once and may
+// apply tokenization, normalization, and validation logic
+// according to their configuration.
+//
+// [Reader] and [ReaderCloser] implement this interface. Consumers should
+// depend on it rather than on the concrete types, so that they can be
+// tested with a fake such as textiotest.FakeTokenReader.
+type TokenReader interface {
+	// ReadTokens reads and returns all available tokens from the input source.
+	//
+	// The returned slice contains the tokens in the order they were read.
+	// An error is returned if tokenization, validation, or reading fails.
+	// In that case, the tokens read before the failure are returned along
+	// with the error. Reaching the end of the input is not an error.
+	//
+	// The input is consumed: calling ReadTokens again reads what is left.
+	//
+	// The optional opts override the configuration for this call only.
+	ReadTokens(opts ...ReadOption) ([]string, error)
+}
+
+// TokenStreamer defines the contract for streaming tokens
+// from an input source to a channel.
+//
+// Implementations must respect context cancellation and stop
+// streaming immediately when the context is done.
+type TokenStreamer interface {
+	// StreamTokens streams tokens from the input source to the provided channel.
+	//
+	// Tokens are sent sequentially to the `out` channel until the input
+	// is exhausted, an error occurs, or the provided context is canceled.
+	// StreamTokens never closes `out`: the caller owns the channel and
+	// typically closes it once StreamTokens has returned.
+	//
+	// If the context is canceled, StreamTokens must return immediately
+	// with ctx.Err(). Reaching the end of the input returns nil.
+	//
+	// The optional opts override the configuration for this call only.
+	StreamTokens(ctx context.Context, out chan string, opts ...ReadOption) error
+}
+
+// TokenReaderStreamer groups batch-oriented and streaming token access.
+//
+// Types implementing this interface support both full reads
+// and incremental streaming of tokens from the same input source.
+type TokenReaderStreamer interface {
+	TokenReader
+	TokenStreamer
+}
+
+// TokenSource is a pull-based stream of tokens, consumed the same way as a [bufio.Scanner].
+//
+// [Reader.Source] returns a TokenSource reading the input of a [Reader], and
+// any type with the same methods, [bufio.Scanner] included, can be used as one.
+type TokenSource interface {
+	// Scan advances to the next token, which is then available through Text.
+	// It returns false once the stream is exhausted or an error occurred.
+	Scan() bool
+	// Text returns the token read by the last call to Scan.
+	Text() string
+	// Err returns the first error encountered by Scan, or nil if the stream
+	// ended normally.
+	Err() error
+}
+
+// TokenWriter is the sink counterpart of [TokenSource].
+//
+// [Writer] implements it, and so can user types receiving tokens,
+// such as database inserters or network clients.
+type TokenWriter = writers.TokenWriter
@@ -1,5 +1,11 @@
 package textio
 
+import (
+	"bufio"
+
+	"github.com/JFinlayM/textio/filters"
+)
+
 // Compile-time interface assertions
 
 var _ TokenReader = (*Reader)(nil)
@@ -13,3 +19,11 @@ var _ TokenReaderStreamer = (*ReaderCloser)(nil)
 var _ TokenReaderCloser = (*ReaderCloser)(nil)
 var _ TokenStreamerCloser = (*ReaderCloser)(nil)
 var _ TokenReaderStreamerCloser = (*ReaderCloser)(nil)
+
+var _ TokenSource = (*readerSource)(nil)
+var _ TokenSource = (*bufio.Scanner)(nil)
+var _ TokenWriter = (*Writer)(nil)
+var _ ProvenanceSource = (*readerSource)(nil)
+var _ ProvenanceSource = (*interleaved)(nil)
+var _ ProvenanceSource = (*sortedMerge)(nil)
+var _ StatefulFilter = (*filters.Dedup)(nil)
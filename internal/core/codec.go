@@ -0,0 +1,115 @@
+package core
+
+import (
+	"compress/bzip2"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Codec encodes and decodes byte streams, such as a compression format.
+//
+// Codecs are registered by name with [RegisterCodec]. The "gzip", "zstd" and "none"
+// codecs are built in, as well as "bzip2" for decoding only. Package textio adds the
+// "auto" codec, which detects the compression format of its input.
+type Codec interface {
+	// Decode returns a reader decoding the data read from r.
+	Decode(r io.Reader) (io.Reader, error)
+	// Encode returns a writer encoding the data written to w. Closing it completes
+	// the encoded stream but does not close w. If it has a Flush() error method,
+	// it is called by [Writer.Flush].
+	Encode(w io.Writer) (io.WriteCloser, error)
+}
+
+var (
+	codecsMu sync.RWMutex
+	codecs   = map[string]Codec{
+		"gzip":  gzipCodec{},
+		"bzip2": bzip2Codec{},
+		"zstd":  zstdCodec{},
+		"none":  noneCodec{},
+	}
+)
+
+// RegisterCodec registers c under name, replacing any codec of the same name.
+// It is safe for concurrent use.
+func RegisterCodec(name string, c Codec) {
+	codecsMu.Lock()
+	defer codecsMu.Unlock()
+	codecs[name] = c
+}
+
+// CodecByName returns the codec registered under name.
+func CodecByName(name string) (Codec, bool) {
+	codecsMu.RLock()
+	defer codecsMu.RUnlock()
+	c, ok := codecs[name]
+	return c, ok
+}
+
+// LookupCodec returns the codec registered under name, or an error.
+func LookupCodec(name string) (Codec, error) {
+	c, ok := CodecByName(name)
+	if !ok {
+		return nil, fmt.Errorf("textio: unknown codec %q", name)
+	}
+	return c, nil
+}
+
+type gzipCodec struct{}
+
+func (gzipCodec) Decode(r io.Reader) (io.Reader, error) {
+	return gzip.NewReader(r)
+}
+
+func (gzipCodec) Encode(w io.Writer) (io.WriteCloser, error) {
+	return gzip.NewWriter(w), nil
+}
+
+type noneCodec struct{}
+
+func (noneCodec) Decode(r io.Reader) (io.Reader, error) {
+	return r, nil
+}
+
+func (noneCodec) Encode(w io.Writer) (io.WriteCloser, error) {
+	return nopWriteCloser{w}, nil
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
+
+type bzip2Codec struct{}
+
+func (bzip2Codec) Decode(r io.Reader) (io.Reader, error) {
+	return bzip2.NewReader(r), nil
+}
+
+func (bzip2Codec) Encode(w io.Writer) (io.WriteCloser, error) {
+	return nil, errors.New("textio: bzip2 codec cannot encode")
+}
+
+type zstdCodec struct{}
+
+func (zstdCodec) Decode(r io.Reader) (io.Reader, error) {
+	// A single goroutine is enough to decode a stream, and leaves
+	// none running when the decoder is not closed.
+	d, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+	if err != nil {
+		return nil, err
+	}
+	return d.IOReadCloser(), nil
+}
+
+func (zstdCodec) Encode(w io.Writer) (io.WriteCloser, error) {
+	return zstd.NewWriter(w)
+}
@@ -0,0 +1,26 @@
+package core
+
+import "fmt"
+
+// conflicts reports whether p and stop are the same pattern, in which
+// case the token pattern always matches first.
+func (p pattern) conflicts(stop pattern) bool {
+	switch {
+	case p.str != "":
+		return p.str == stop.str && p.fold == stop.fold
+	case p.re != nil:
+		return stop.re != nil && p.re.String() == stop.re.String()
+	}
+	return false
+}
+
+// String returns a description of p for error messages.
+func (p pattern) String() string {
+	switch {
+	case p.re != nil:
+		return fmt.Sprintf("`%s`", p.re)
+	case p.fn != nil:
+		return "a rune class"
+	}
+	return fmt.Sprintf("%q", p.str)
+}
@@ -0,0 +1,698 @@
+package core
+
+import (
+	"bufio"
+	"bytes"
+	"regexp"
+	"regexp/syntax"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+type Delimiter struct {
+	token pattern
+	stop  pattern
+	// split replaces the pattern based split function when set,
+	// as with [ScanBytes].
+	split bufio.SplitFunc
+	// trailingEmpty makes input ending with a token delimiter
+	// yield a final empty token, see [Delimiter.SetTrailingEmpty].
+	trailingEmpty bool
+	// leading is the policy applied to a token delimiter
+	// found at the very start of the input.
+	leading EmptyPolicy
+	// longest makes regular expression patterns prefer the
+	// leftmost-longest match, see [Delimiter.SetLongestMatch].
+	longest bool
+	// emitDelims makes token delimiters emitted as tokens of kind
+	// [KindDelimiter], see [Delimiter.SetEmitDelimiters].
+	emitDelims bool
+	// keepCR disables the removal of the "\r" ending tokens
+	// separated by "\n", see [Delimiter.SetKeepCR].
+	keepCR bool
+	// joiner is the separator used by [Join], see [Delimiter.SetJoiner].
+	joiner *string
+	// collapse makes runs of token delimiters count as a single one,
+	// see [Delimiter.CollapseRuns].
+	collapse bool
+	// matchLimit and matchTimeout guard regular expression matching,
+	// see [Delimiter.SetMatchLimit] and [Delimiter.SetMatchTimeout].
+	matchLimit   int
+	matchTimeout time.Duration
+	// quote encloses regions where delimiters are ignored, see [Delimiter.SetQuote].
+	quote []byte
+}
+
+// By contruction, [regexpr] and [str] cannot be set at the same time.
+type pattern struct {
+	// Delimiter as a regular expression
+	re *regexp.Regexp
+	// String delimiter
+	str string
+	// fold makes str match case-insensitively.
+	fold bool
+	// Delimiter as a class of runes
+	fn func(rune) bool
+}
+
+// Sets the regexpr delimiter.
+// This resets the [str] field of `d`.
+func (d *Delimiter) SetTokenRegexp(regexpr *regexp.Regexp) {
+	d.split = nil
+	d.token = pattern{re: regexpr}
+}
+
+// Sets the [str] field of `d` used to seperate input into tokens.
+// This resets the [delimiter] field of `d`.
+func (d *Delimiter) SetTokenStr(s string) {
+	d.split = nil
+	d.token = pattern{str: s}
+}
+
+// Sets the [str] field of `d` used to seperate input into tokens, matched case-insensitively
+// (under Unicode simple case folding) without resorting to a regular expression.
+// This resets the [delimiter] field of `d`.
+func (d *Delimiter) SetTokenStrFold(s string) {
+	d.split = nil
+	d.token = pattern{str: s, fold: true}
+}
+
+// Sets a class of runes used to seperate input into tokens, as with [strings.FieldsFunc]:
+// any run of consecutive runes c satisfying f(c) is a delimiter, for example
+// [unicode.IsSpace] or [unicode.IsPunct]. This is faster than an equivalent regular expression.
+// This resets the [str] and [delimiter] fields of `d`.
+func (d *Delimiter) SetTokenFunc(f func(rune) bool) {
+	d.split = nil
+	d.token = pattern{fn: f}
+}
+
+// Sets the regexpr delimiter from an expression in string format.
+// This resets the [str] field of `d`.
+// This function will panic if the expression cannot compile.
+func (d *Delimiter) SetTokenRegexpFromString(expr string) {
+	if expr == "" {
+		panic("empty regexp is not allowed")
+	}
+	regexpr := regexp.MustCompile(expr)
+	d.split = nil
+	d.token = pattern{re: regexpr}
+}
+
+// Sets the regexpr delimiter.
+// This resets the [str] field of `d`.
+func (d *Delimiter) SetStopRegexp(regexpr *regexp.Regexp) {
+	d.stop.re = regexpr
+	d.stop.str = ""
+}
+
+// Sets the [str] field of `d` used to seperate input into tokens.
+// This resets the [delimiter] field of `d`.
+func (d *Delimiter) SetStopStr(s string) {
+	d.stop.re = nil
+	d.stop.str = s
+}
+
+// Sets the regexpr delimiter from an expression in string format.
+// This resets the [str] field of `d`.
+// This function will panic if the expression cannot compile.
+func (d *Delimiter) SetStopRegexpFromString(expr string) {
+	if expr == "" {
+		panic("empty regexp is not allowed")
+	}
+	regexpr := regexp.MustCompile(expr)
+	d.stop.re = regexpr
+	d.stop.str = ""
+}
+
+func (d Delimiter) WithTokenRegexp(regexpr *regexp.Regexp) *Delimiter {
+	d.token = pattern{re: regexpr}
+	d.split = nil
+	return &d
+}
+
+func (d Delimiter) WithTokenStr(s string) *Delimiter {
+	d.token = pattern{str: s}
+	d.split = nil
+	return &d
+}
+
+func (d Delimiter) WithTokenStrFold(s string) *Delimiter {
+	d.token = pattern{str: s, fold: true}
+	d.split = nil
+	return &d
+}
+
+func (d Delimiter) WithTokenFunc(f func(rune) bool) *Delimiter {
+	d.token = pattern{fn: f}
+	d.split = nil
+	return &d
+}
+
+func (d Delimiter) WithTokenRegexpFromString(s string) *Delimiter {
+	if s == "" {
+		panic("empty regexp is not allowed")
+	}
+	d.token = pattern{re: regexp.MustCompile(s)}
+	d.split = nil
+	return &d
+}
+
+func (d Delimiter) WithStopRegexp(regexpr *regexp.Regexp) *Delimiter {
+	d.stop = pattern{re: regexpr}
+	return &d
+}
+
+func (d Delimiter) WithStopStr(s string) *Delimiter {
+	d.stop = pattern{str: s}
+	return &d
+}
+
+func (d Delimiter) WithStopRegexpFromString(s string) *Delimiter {
+	if s == "" {
+		panic("empty regexp is not allowed")
+	}
+
+	d.stop = pattern{re: regexp.MustCompile(s)}
+	return &d
+}
+
+// SplitFunc returns a [bufio.SplitFunc] splitting input according to d.
+// Each call returns a new function with its own state, to be used for a single input.
+func (d *Delimiter) SplitFunc() bufio.SplitFunc {
+	return d.splitFunc(nil)
+}
+
+// splitFunc is [Delimiter.SplitFunc] also reporting, if kind is not nil,
+// the [TokenKind] of each returned token.
+func (d *Delimiter) splitFunc(kind *TokenKind) bufio.SplitFunc {
+	if d.split != nil {
+		return d.split
+	}
+	tokenPat, stopPat := d.token, d.stop
+	if d.longest {
+		tokenPat, stopPat = tokenPat.longestMatch(), stopPat.longestMatch()
+	}
+
+	// afterDelim is set when the last token was ended by a token delimiter,
+	// and started once some input has been consumed.
+	afterDelim, started := false, false
+	// pending is the width of the delimiter to emit next, when d emits delimiters.
+	pending := 0
+	stopLen := stopPat.maxLen()
+	guard := &matchGuard{limit: d.matchLimit, timeout: d.matchTimeout}
+	setKind := func(k TokenKind) {
+		if kind != nil {
+			*kind = k
+		}
+	}
+	return func(data []byte, atEOF bool) (advance int, token []byte, err error) {
+		setKind(KindContent)
+		if pending > 0 {
+			w := pending
+			pending = 0
+			setKind(KindDelimiter)
+			return w, data[:w], nil
+		}
+
+		// Nothing left
+		if atEOF && len(data) == 0 {
+			if afterDelim && d.trailingEmpty && !d.collapse {
+				afterDelim = false
+				return 0, []byte{}, bufio.ErrFinalToken
+			}
+			return 0, nil, bufio.ErrFinalToken
+		}
+		afterDelim = false
+
+		// Locate delimiters
+		tokenIdx, tokenW, err := d.find(guard, &tokenPat, data, true)
+		if err != nil {
+			return 0, nil, err
+		}
+
+		stopIdx, stopW := -1, 0
+		if stopPat.enabled() {
+			if stopIdx, stopW, err = d.find(guard, &stopPat, data, false); err != nil {
+				return 0, nil, err
+			}
+		}
+
+		if stopIdx >= 0 && (tokenIdx < 0 || stopIdx < tokenIdx) {
+			if stopPat.mayExtend(data, stopIdx, stopW, atEOF) {
+				return 0, nil, nil
+			}
+
+			// Return data before stop as final token
+			if stopIdx > 0 {
+				started = true
+				return stopIdx, d.dropCR(data[:stopIdx]), nil
+			}
+
+			// Stop delimiter at beginning: consume and stop
+			started = true
+			return stopW, nil, bufio.ErrFinalToken
+		}
+
+		if tokenIdx >= 0 {
+			if tokenPat.mayExtend(data, tokenIdx, tokenW, atEOF) {
+				return 0, nil, nil
+			}
+			if !atEOF && stopPat.enabled() && stopPat.partialBefore(data, tokenIdx, stopLen) {
+				// A stop pattern cut by the end of the buffer may start before
+				// the token delimiter, and win once the next bytes are read.
+				return 0, nil, nil
+			}
+			if d.collapse {
+				end := tokenIdx + tokenW
+				for {
+					i, w := tokenPat.find(data[end:])
+					if i != 0 || w == 0 {
+						break
+					}
+					end += w
+				}
+				if !atEOF && int64(len(data)-end) < max(d.lookback(), 1) {
+					// The run may go on in the next bytes.
+					return 0, nil, nil
+				}
+				tokenW = end - tokenIdx
+			}
+			if tokenIdx == 0 && !started {
+				leading := d.leading
+				if d.collapse {
+					leading = EmptySkip
+				}
+				switch leading {
+				case EmptySkip:
+					started = true
+					if d.emitDelims {
+						afterDelim = true
+						setKind(KindDelimiter)
+						return tokenW, data[:tokenW], nil
+					}
+					return tokenW, nil, nil
+				case EmptyError:
+					return 0, nil, ErrLeadingDelimiter
+				}
+			}
+			started = true
+			afterDelim = true
+			content := d.dropCR(data[:tokenIdx])
+			if d.emitDelims {
+				// Emit the content now and the delimiter, including
+				// a dropped "\r", on the next call.
+				pending = tokenIdx - len(content) + tokenW
+				return len(content), content, nil
+			}
+			return tokenIdx + tokenW, content, nil
+		}
+
+		if atEOF {
+			return len(data), d.dropCR(data), nil
+		}
+
+		// Need more data
+		return 0, nil, nil
+	}
+}
+
+// find returns the index and width of the first match of p in data
+// lying outside quoted regions, see [Delimiter.SetQuote].
+//
+// Since data always starts at the beginning of a token, which is outside
+// quotes, the quote state is rebuilt on each call, including after the
+// buffer was refilled. A quote left open in data yields no match, so that
+// more data is read.
+func (d *Delimiter) find(guard *matchGuard, p *pattern, data []byte, required bool) (int, int, error) {
+	if d.quote == nil {
+		return guard.find(p, data, required)
+	}
+	from, pos, quoted := 0, 0, false
+	for {
+		idx, w, err := guard.find(p, data[from:], required)
+		if err != nil || idx < 0 {
+			return idx, w, err
+		}
+		idx += from
+		for {
+			q := bytes.Index(data[pos:idx], d.quote)
+			if q < 0 {
+				break
+			}
+			quoted = !quoted
+			pos += q + len(d.quote)
+		}
+		if !quoted {
+			return idx, w, nil
+		}
+		q := bytes.Index(data[idx:], d.quote)
+		if q < 0 {
+			return -1, 0, nil
+		}
+		pos = idx + q + len(d.quote)
+		from, quoted = pos, false
+	}
+}
+
+// mayExtend reports whether a regular expression or rune class match at data[idx:idx+width]
+// touches the end of the buffer while more input is expected, in which case
+// the match could extend further (e.g. `\s+`) and more data must be read
+// before committing to it.
+func (p *pattern) mayExtend(data []byte, idx, width int, atEOF bool) bool {
+	return (p.re != nil || p.fn != nil) && !atEOF && idx+width == len(data)
+}
+
+// partialBefore reports whether a match of p, of at most maxLen bytes (see
+// [pattern.maxLen]), may start before idx and be cut by the end of data.
+// String patterns are checked exactly, while bounded regular expressions are
+// assumed to match as long as the bytes after idx are too few to tell.
+// Unbounded regular expressions are never assumed to match.
+func (p *pattern) partialBefore(data []byte, idx, maxLen int) bool {
+	if p.str != "" {
+		for i := max(0, len(data)-len(p.str)+1); i < idx; i++ {
+			tail := data[i:]
+			if p.fold && bytes.EqualFold(tail, []byte(p.str[:len(tail)])) || bytes.HasPrefix([]byte(p.str), tail) {
+				return true
+			}
+		}
+		return false
+	}
+	return p.re != nil && maxLen > 0 && idx > 0 && idx-1+maxLen > len(data)
+}
+
+// maxLen returns the maximum length in bytes of a match of p,
+// or -1 if it is unbounded.
+func (p *pattern) maxLen() int {
+	switch {
+	case p.str != "":
+		return len(p.str)
+	case p.re != nil:
+		re, err := syntax.Parse(p.re.String(), syntax.Perl)
+		if err != nil {
+			return -1
+		}
+		return syntaxMaxLen(re.Simplify())
+	}
+	return -1
+}
+
+// syntaxMaxLen returns the maximum length in bytes of a match of re,
+// or -1 if it is unbounded.
+func syntaxMaxLen(re *syntax.Regexp) int {
+	switch re.Op {
+	case syntax.OpEmptyMatch, syntax.OpBeginLine, syntax.OpEndLine, syntax.OpBeginText,
+		syntax.OpEndText, syntax.OpWordBoundary, syntax.OpNoWordBoundary, syntax.OpNoMatch:
+		return 0
+	case syntax.OpLiteral:
+		if re.Flags&syntax.FoldCase != 0 {
+			return len(re.Rune) * utf8.UTFMax
+		}
+		n := 0
+		for _, r := range re.Rune {
+			n += utf8.RuneLen(r)
+		}
+		return n
+	case syntax.OpCharClass, syntax.OpAnyChar, syntax.OpAnyCharNotNL:
+		return utf8.UTFMax
+	case syntax.OpCapture:
+		return syntaxMaxLen(re.Sub[0])
+	case syntax.OpQuest:
+		return syntaxMaxLen(re.Sub[0])
+	case syntax.OpRepeat:
+		n := syntaxMaxLen(re.Sub[0])
+		if re.Max < 0 || n < 0 {
+			return -1
+		}
+		return re.Max * n
+	case syntax.OpConcat, syntax.OpAlternate:
+		total := 0
+		for _, sub := range re.Sub {
+			n := syntaxMaxLen(sub)
+			if n < 0 {
+				return -1
+			}
+			if re.Op == syntax.OpConcat {
+				total += n
+			} else {
+				total = max(total, n)
+			}
+		}
+		return total
+	}
+	// OpStar, OpPlus
+	return -1
+}
+
+// lookback returns how many bytes before an arbitrary offset must be read
+// to tell whether that offset is the start of a token, or -1 if there is no
+// such bound, as for regular expressions with unbounded matches like `\s+`.
+func (d *Delimiter) lookback() int64 {
+	switch {
+	case d.split != nil:
+		return 0
+	case d.token.str != "":
+		return int64(len(d.token.str))
+	case d.token.fn != nil:
+		return utf8.UTFMax
+	}
+	n := d.token.maxLen()
+	if n < 0 {
+		return -1
+	}
+	return int64(max(n, 1))
+}
+
+// SetTrailingEmpty controls whether input ending with a token delimiter yields
+// a final empty token.
+//
+// By default it does not, which matches line-oriented semantics: "a\nb\n" gives
+// ["a", "b"]. Passing true matches [strings.Split] semantics instead: "a,b," split
+// on "," gives ["a", "b", ""]. Empty input yields no token in both cases.
+func (d *Delimiter) SetTrailingEmpty(emit bool) {
+	d.trailingEmpty = emit
+}
+
+// EmptyPolicy decides what happens to an empty token produced by a delimiter.
+type EmptyPolicy int
+
+const (
+	// EmptyEmit emits the empty token. This is the default.
+	EmptyEmit EmptyPolicy = iota
+	// EmptySkip drops the empty token.
+	EmptySkip
+	// EmptyError fails the read.
+	EmptyError
+)
+
+// SetLeading sets the policy applied when the input starts with a token delimiter.
+//
+// With [EmptyEmit] (the default) a leading empty token is produced, [EmptySkip]
+// drops it, and [EmptyError] makes the read fail with [ErrLeadingDelimiter],
+// which is useful for strict record formats.
+func (d *Delimiter) SetLeading(p EmptyPolicy) {
+	d.leading = p
+}
+
+// SetLongestMatch chooses how regular expression patterns match.
+//
+// By default, the leftmost-first match is used, as with Perl-like expressions:
+// `-|--` consumes a single "-" of "--". Passing true selects the leftmost-longest
+// match instead, so the longest alternative at the match position is consumed,
+// which changes how runs of delimiters are split. See [regexp.Regexp.Longest].
+func (d *Delimiter) SetLongestMatch(longest bool) {
+	d.longest = longest
+}
+
+// longestMatch returns a copy of p whose regular expression, if any,
+// prefers leftmost-longest matches. The original expression is not modified.
+func (p pattern) longestMatch() pattern {
+	if p.re != nil {
+		re := regexp.MustCompile(p.re.String())
+		re.Longest()
+		p.re = re
+	}
+	return p
+}
+
+// SetEmitDelimiters controls whether the token delimiters found in the input are
+// emitted as tokens of their own, of kind [KindDelimiter].
+//
+// The content tokens are the same as when delimiters are not emitted; each
+// delimiter token follows the content token it ends, and holds the exact bytes
+// of the delimiter (including a "\r" dropped from a line, see [Delimiter.SetKeepCR]).
+// Delimiter tokens are not normalized nor filtered, so concatenating all the
+// tokens of an input reproduces it exactly as long as no content token is
+// rejected or modified. Use [Reader.ReadTagged] to tell the kinds apart.
+// Stop patterns are never emitted.
+func (d *Delimiter) SetEmitDelimiters(emit bool) {
+	d.emitDelims = emit
+}
+
+// CollapseRuns controls whether runs of consecutive token delimiters count as a single
+// delimiter, for string delimiters as well as regular expressions. Collapsed delimiters
+// never produce empty tokens: leading and trailing delimiters are dropped whatever
+// the [Delimiter.SetLeading] and [Delimiter.SetTrailingEmpty] settings, in the manner
+// of [strings.Fields]. See [FieldsPreset].
+func (d *Delimiter) CollapseRuns(collapse bool) {
+	d.collapse = collapse
+}
+
+// SetQuote makes the delimiter ignore token and stop delimiters inside regions enclosed
+// by quote, such as '"' for CSV-like input: "\"San Francisco, CA\",US" split on ","
+// gives ["\"San Francisco, CA\"", "US"]. A doubled quote inside a quoted region, as
+// escaped in CSV, keeps the region open. Tokens keep their quotes, which can be removed
+// with [NormalizeUnquote]. A quote left open runs to the end of the input.
+// A quote of 0 disables quoting, which is the default.
+//
+// Quoted regions are not detected by [ReaderCloser.FromFileRange], whose ranges
+// must not start inside quotes.
+func (d *Delimiter) SetQuote(quote rune) {
+	if quote == 0 {
+		d.quote = nil
+		return
+	}
+	d.quote = utf8.AppendRune(nil, quote)
+}
+
+// SetJoiner sets the separator written between tokens by [Join].
+// It is needed for delimiters having no string form, such as regular expressions.
+func (d *Delimiter) SetJoiner(sep string) {
+	d.joiner = &sep
+}
+
+// SetKeepCR controls the handling of "\r\n" line endings.
+//
+// By default, when tokens are separated by "\n", a "\r" ending a token is
+// dropped in the split stage, like [bufio.ScanLines] does, so that files with
+// Windows line endings give the same tokens whatever the normalization function.
+// Passing true keeps the "\r" in the tokens.
+func (d *Delimiter) SetKeepCR(keep bool) {
+	d.keepCR = keep
+}
+
+// dropCR removes the "\r" ending token when d splits lines with "\n".
+func (d *Delimiter) dropCR(token []byte) []byte {
+	if d.keepCR || d.token.str != "\n" {
+		return token
+	}
+	if n := len(token); n > 0 && token[n-1] == '\r' {
+		return token[:n-1]
+	}
+	return token
+}
+
+func (p pattern) enabled() bool {
+	return p.re != nil || p.str != "" || p.fn != nil
+}
+
+func (p *pattern) find(data []byte) (idx int, width int) {
+	if p == nil {
+		return -1, 0
+	}
+
+	if p.re != nil {
+		loc := p.re.FindIndex(data)
+		if loc == nil {
+			return -1, 0
+		}
+		return loc[0], loc[1] - loc[0]
+	}
+
+	if p.fn != nil {
+		return indexFunc(data, p.fn)
+	}
+
+	if p.fold && p.str != "" {
+		return indexFold(data, p.str)
+	}
+
+	if len(p.str) == 1 {
+		idx := bytes.IndexByte(data, p.str[0])
+		if idx < 0 {
+			return -1, 0
+		}
+		return idx, 1
+	}
+
+	if p.str != "" {
+		idx := bytes.Index(data, []byte(p.str))
+		if idx < 0 {
+			return -1, 0
+		}
+		return idx, len(p.str)
+	}
+
+	return -1, 0
+}
+
+// indexFunc returns the index and width of the first run of runes
+// satisfying f in data, or -1 if there is none.
+func indexFunc(data []byte, f func(rune) bool) (int, int) {
+	start := -1
+	for i := 0; i < len(data); {
+		if !utf8.FullRune(data[i:]) {
+			// Rune cut by the end of the buffer.
+			break
+		}
+		c, size := utf8.DecodeRune(data[i:])
+		if f(c) {
+			if start < 0 {
+				start = i
+			}
+		} else if start >= 0 {
+			return start, i - start
+		}
+		i += size
+	}
+	if start < 0 {
+		return -1, 0
+	}
+	return start, len(data) - start
+}
+
+// indexFold returns the index and width of the first case-insensitive match
+// of s in data, or -1 if there is none.
+func indexFold(data []byte, s string) (int, int) {
+	first, _ := utf8.DecodeRuneInString(s)
+	for i := 0; i < len(data); {
+		c, size := utf8.DecodeRune(data[i:])
+		if equalFoldRune(c, first) {
+			if w := prefixFold(data[i:], s); w >= 0 {
+				return i, w
+			}
+		}
+		i += size
+	}
+	return -1, 0
+}
+
+// prefixFold returns the length of the prefix of data matching s
+// case-insensitively, or -1 if data does not start with s.
+func prefixFold(data []byte, s string) int {
+	i := 0
+	for _, sc := range s {
+		if i >= len(data) {
+			return -1
+		}
+		c, size := utf8.DecodeRune(data[i:])
+		if !equalFoldRune(c, sc) {
+			return -1
+		}
+		i += size
+	}
+	return i
+}
+
+// equalFoldRune reports whether a and b are equal under simple case folding.
+func equalFoldRune(a, b rune) bool {
+	if a == b {
+		return true
+	}
+	for f := unicode.SimpleFold(a); f != a; f = unicode.SimpleFold(f) {
+		if f == b {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,85 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// type ReaderErrorKind int
+
+var (
+	ErrInvalid             = errors.New("textio: invalid token")
+	ErrRead                = errors.New("textio: read error")
+	ErrClose               = errors.New("textio: close error")
+	ErrOutputBufferBlocked = errors.New("textio: output buffer is blocked")
+	ErrOpen                = errors.New("textio: open error")
+	ErrMaxBytes            = errors.New("textio: input exceeds byte budget")
+	ErrBinaryInput         = errors.New("textio: binary input")
+	ErrLeadingDelimiter    = errors.New("textio: input starts with a delimiter")
+	ErrWrite               = errors.New("textio: write error")
+	ErrLimit               = errors.New("textio: too many tokens")
+	ErrPatternTimeout      = errors.New("textio: pattern matching budget exceeded")
+	ErrLengthMismatch      = errors.New("textio: token sources differ in length")
+	ErrConfig              = errors.New("textio: invalid configuration")
+)
+
+type ReaderError struct {
+	Kind error
+	Err  error
+	// Metadata
+	Token string
+	Index int
+	// Source and Offset locate Token in the input, when known
+	// (Offset is -1 otherwise), see [TokenInfo].
+	Source    string
+	Offset    int64
+	FileName  string
+	FuncName  string
+	ErrorLine int
+}
+
+func (e *ReaderError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%v: %v", e.Kind, e.Err)
+	}
+	return e.Kind.Error()
+}
+
+func (e *ReaderError) Is(target error) bool {
+	return e.Kind == target
+}
+
+func (e *ReaderError) Unwrap() error {
+	return e.Err
+}
+
+// NewReaderError returns a [ReaderError] locating the caller skip frames up the
+// stack, see [runtime.Caller], whose Kind and Err are to be set.
+func NewReaderError(skip int) *ReaderError {
+	pc, file, line, _ := runtime.Caller(skip)
+
+	fileName := file
+	if i := strings.LastIndex(file, "/"); i >= 0 {
+		fileName = file[i+1:]
+	}
+
+	funcName := ""
+	if fn := runtime.FuncForPC(pc); fn != nil {
+		name := fn.Name()
+		if i := strings.LastIndex(name, "."); i >= 0 {
+			funcName = name[i+1:]
+		} else {
+			funcName = name
+		}
+	}
+
+	return &ReaderError{
+		FileName:  fileName,
+		FuncName:  funcName,
+		ErrorLine: line,
+		Index:     -1,
+		Offset:    -1,
+	}
+}
@@ -0,0 +1,77 @@
+package core
+
+import (
+	"bufio"
+	"fmt"
+)
+
+// The functions below give package textio and its subpackages access to the
+// state of a [Delimiter], without adding methods to its public API.
+
+// NewSplitDelimiter returns a [Delimiter] splitting its input with split,
+// in place of token and stop patterns.
+func NewSplitDelimiter(split bufio.SplitFunc) *Delimiter {
+	return &Delimiter{split: split}
+}
+
+// SplitFunc is [Delimiter.SplitFunc] also reporting, if kind is not nil,
+// the [TokenKind] of each returned token.
+func SplitFunc(d *Delimiter, kind *TokenKind) bufio.SplitFunc {
+	return d.splitFunc(kind)
+}
+
+// JoinSep returns the separator written between tokens by d.
+func JoinSep(d *Delimiter) string {
+	return d.joinSep()
+}
+
+// QuoteToken returns s enclosed in the quote of d, with its quotes doubled, if d
+// would not split s back as a single token.
+func QuoteToken(d *Delimiter, s string) string {
+	return d.quoteToken(s)
+}
+
+// Quote returns the quote of d, or nil if it has none.
+func Quote(d *Delimiter) []byte {
+	return d.quote
+}
+
+// EmitsDelimiters reports whether d emits its token delimiters as tokens.
+func EmitsDelimiters(d *Delimiter) bool {
+	return d.emitDelims
+}
+
+// Lookback returns how many bytes before an arbitrary offset must be read to tell
+// whether that offset is the start of a token, or -1 if there is no such bound.
+func Lookback(d *Delimiter) int64 {
+	return d.lookback()
+}
+
+// TokenPattern returns a description of the token pattern of d for error messages.
+func TokenPattern(d *Delimiter) string {
+	return d.token.String()
+}
+
+// Problems returns a description of each problem in the configuration of d,
+// such as a missing token pattern or patterns matching the empty string.
+func Problems(d *Delimiter) []string {
+	if d.split != nil {
+		return nil
+	}
+	var problems []string
+	if !d.token.enabled() {
+		problems = append(problems, "has no token pattern")
+	}
+	if d.token.conflicts(d.stop) {
+		problems = append(problems, fmt.Sprintf("token and stop patterns are both %s, the stop pattern never matches", d.token))
+	}
+	for _, p := range []struct {
+		name string
+		pattern
+	}{{"token", d.token}, {"stop", d.stop}} {
+		if p.re != nil && p.re.MatchString("") {
+			problems = append(problems, fmt.Sprintf("%s pattern %s matches the empty string", p.name, p.pattern))
+		}
+	}
+	return problems
+}
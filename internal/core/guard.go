@@ -0,0 +1,81 @@
+package core
+
+import (
+	"fmt"
+	"time"
+)
+
+// SetMatchLimit caps the number of bytes a regular expression delimiter may examine
+// when looking for its next match. If no match is found within the first n bytes of
+// the remaining input, the read fails with [ErrPatternTimeout]. Matches are only
+// searched for within that window, and a match reaching its end, which could go on
+// past it, is not accepted; a stop pattern not found there is not an error, as the
+// input normally has no stop. A value of 0 or less removes the cap.
+//
+// String and rune class delimiters are not affected.
+func (d *Delimiter) SetMatchLimit(n int) {
+	d.matchLimit = n
+}
+
+// SetMatchTimeout sets the total time regular expression delimiters may spend
+// matching during a single read. Past that budget, the read fails with
+// [ErrPatternTimeout]. A value of 0 or less removes the budget.
+//
+// String and rune class delimiters are not affected.
+func (d *Delimiter) SetMatchTimeout(timeout time.Duration) {
+	d.matchTimeout = timeout
+}
+
+// patternError is the error returned by a split function exceeding the
+// limits set with [Delimiter.SetMatchLimit] and [Delimiter.SetMatchTimeout].
+type patternError struct {
+	msg string
+}
+
+func (e *patternError) Error() string {
+	return e.msg
+}
+
+func (e *patternError) Is(target error) bool {
+	return target == ErrPatternTimeout
+}
+
+// matchGuard looks for the regular expression patterns of a [Delimiter]
+// within its match limits, accounting the time spent matching.
+type matchGuard struct {
+	limit   int
+	timeout time.Duration
+	spent   time.Duration
+}
+
+// find looks for p in data. If required is set, as for the token pattern, finding
+// no match within the limit is an error; otherwise, as for the stop pattern, which
+// is normally absent from the input, it only means there is no match.
+// A match reaching the end of the window could go on past the limit, and does
+// not count as a match.
+func (g *matchGuard) find(p *pattern, data []byte, required bool) (int, int, error) {
+	if p.re == nil || (g.limit <= 0 && g.timeout <= 0) {
+		idx, width := p.find(data)
+		return idx, width, nil
+	}
+
+	window := data
+	if g.limit > 0 && len(window) > g.limit {
+		window = window[:g.limit]
+	}
+	start := time.Now()
+	idx, width := p.find(window)
+	if g.timeout > 0 {
+		g.spent += time.Since(start)
+		if g.spent > g.timeout {
+			return -1, 0, &patternError{fmt.Sprintf("matching took more than %v", g.timeout)}
+		}
+	}
+	if idx >= 0 && len(window) < len(data) && p.mayExtend(window, idx, width, false) {
+		idx, width = -1, 0
+	}
+	if required && idx < 0 && len(window) < len(data) {
+		return -1, 0, &patternError{fmt.Sprintf("no match in %d bytes", g.limit)}
+	}
+	return idx, width, nil
+}
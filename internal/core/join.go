@@ -0,0 +1,31 @@
+package core
+
+import "strings"
+
+// quoteToken returns s enclosed in the quote of d, with its quotes doubled, if d would
+// not split s back as a single token. s is returned as is when d has no quote.
+func (d *Delimiter) quoteToken(s string) string {
+	if d.quote == nil {
+		return s
+	}
+	q := string(d.quote)
+	sep := d.joinSep()
+	if idx, _ := d.token.find([]byte(s)); idx < 0 && !strings.Contains(s, q) &&
+		!strings.ContainsAny(s, "\r\n") && (sep == "" || !strings.Contains(s, sep)) {
+		return s
+	}
+	return q + strings.ReplaceAll(s, q, q+q) + q
+}
+
+// joinSep returns the separator used by [Join].
+func (d *Delimiter) joinSep() string {
+	switch {
+	case d.joiner != nil:
+		return *d.joiner
+	case d.split != nil:
+		return ""
+	case d.token.str != "":
+		return d.token.str
+	}
+	return "\n"
+}
@@ -0,0 +1,34 @@
+// Package core holds the types shared by package textio and its delimiters and
+// writers subpackages: delimiters, tokens, codecs and errors. Package textio
+// re-exports them under their usual names.
+package core
+
+// TokenKind tells apart the tokens read by a [Reader].
+type TokenKind int
+
+const (
+	// KindContent is a token of the input content.
+	KindContent TokenKind = iota
+	// KindDelimiter is a delimiter found in the input,
+	// emitted when [Delimiter.SetEmitDelimiters] is set.
+	KindDelimiter
+)
+
+// String returns the name of the kind.
+func (k TokenKind) String() string {
+	switch k {
+	case KindContent:
+		return "content"
+	case KindDelimiter:
+		return "delimiter"
+	}
+	return "unknown"
+}
+
+// Token is a token read by a [Reader] along with its kind.
+type Token struct {
+	Text string
+	Kind TokenKind
+	// Lang is the language of content tokens, see [Reader.SetLanguageDetector].
+	Lang string
+}
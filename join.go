@@ -0,0 +1,23 @@
+package textio
+
+import "github.com/JFinlayM/textio/delimiters"
+
+// Join concatenates tokens into a single string separated according to d, making
+// emitting data symmetric with parsing.
+//
+// Tokens are separated by the joiner of d if set with [Delimiter.SetJoiner], otherwise by
+// the string form of its token delimiter. Delimiters without a string form (regular
+// expressions, rune classes) fall back to "\n", and [ScanBytes] to no separator.
+// A nil d is the [DefaultDelimiter].
+//
+// When d has a quote (see [Delimiter.SetQuote]), tokens containing the separator, a
+// delimiter, the quote or a line break are enclosed in the quote, their quotes doubled,
+// so that Join([]string{"San Francisco, CA", "US"}, CSVPreset()) gives
+// "\"San Francisco, CA\",US", read back as the same tokens with [NormalizeUnquote].
+//
+// Without a quote, tokens are written as is: d splits the result back into the same
+// tokens only if none of them contains the separator or a delimiter of d. For example,
+// Join([]string{"a\nb", "c"}, nil) gives "a\nb\nc", read back as three tokens.
+func Join(tokens []string, d *Delimiter) string {
+	return delimiters.Join(tokens, d)
+}
@@ -0,0 +1,39 @@
+package textio
+
+// SetLanguageDetector sets the function called with each content token, before
+// normalization, to find its language, such as an ISO 639 code like "en" or "fr",
+// or an empty string if unknown. The language is exposed as the Lang field of
+// [TokenInfo] and [Token], so multilingual input can be routed to language-specific
+// normalizers (see [NormalizeByLang]) and consumers, for example with [Reader.ReadTagged].
+// A nil detect, the default, disables detection.
+//
+// textio does not ship a detector: detect typically wraps a language identification
+// library, or uses a cheap heuristic such as the Unicode script of the token.
+func (r *Reader) SetLanguageDetector(detect func(string) string) {
+	r.detectLang = detect
+}
+
+// NormalizeByLang returns a [NormalizeFuncInfo] applying to each token the normalizer
+// of its language in byLang (see [Reader.SetLanguageDetector]), or fallback for the
+// other languages. A nil normalizer leaves the tokens unchanged.
+func NormalizeByLang(byLang map[string]NormalizeFunc, fallback NormalizeFunc) NormalizeFuncInfo {
+	return func(info TokenInfo) string {
+		n, ok := byLang[info.Lang]
+		if !ok {
+			n = fallback
+		}
+		if n == nil {
+			return info.Text
+		}
+		return n(info.Text)
+	}
+}
+
+// Lang returns the language of the token read by the last call to Scan,
+// see [Reader.SetLanguageDetector].
+func (s *readerSource) Lang() string {
+	if s.it.kind != KindContent {
+		return ""
+	}
+	return s.it.lang
+}
@@ -0,0 +1,42 @@
+package textio
+
+// MapFunc rewrites the token s into the tokens it returns, which are then filtered
+// one by one in order. Unlike a [NormalizeFunc], it can drop a token by returning no
+// token, or split it by returning several. When ok is false, s is kept unchanged,
+// which saves allocating a slice for the tokens left as is.
+type MapFunc func(s string) (tokens []string, ok bool)
+
+// SetMapper sets the function rewriting the tokens between normalization and filtering.
+// There is none by default.
+//
+// The tokens produced from a token share its index and position (see [TokenInfo]).
+// The limit set with [Reader.SetLimit] counts the tokens produced that are accepted,
+// while [Reader.SetMaxTokens] counts the tokens read from the input.
+func (r *Reader) SetMapper(m MapFunc) {
+	r.mapper = m
+}
+
+// WithMapper returns a shallow copy of the [Reader]
+// configured with the given map function.
+//
+// The original [Reader] is not modified.
+func (r *Reader) WithMapper(m MapFunc) *Reader {
+	newR := r.clone()
+	newR.SetMapper(m)
+	return newR
+}
+
+// nextMapped filters the pending tokens produced by the mapper until one is accepted,
+// and returns it with ok set. It returns the error ending the read, if any.
+func (it *tokenIter) nextMapped() (token string, ok bool, err error) {
+	for len(it.mapped) > 0 {
+		token, it.mapped = it.mapped[0], it.mapped[1:]
+		if ok, err = it.admit(token, it.mappedInfo); err != nil {
+			return "", false, err
+		}
+		if ok {
+			return token, true, nil
+		}
+	}
+	return "", false, nil
+}
@@ -0,0 +1,210 @@
+package textio
+
+import (
+	"container/heap"
+	"context"
+)
+
+// Merge returns a [TokenSource] interleaving the tokens of sources: one token of
+// each source in turn, skipping the sources that are exhausted.
+//
+// The merged source stops at the first error of a source, which is then returned
+// by its Err method, or with ctx.Err() once ctx is done; the other sources are then
+// closed if they have a Close method. The Close method of the merged source closes
+// the sources not read to the end, see [Reader.Source].
+func Merge(ctx context.Context, sources ...TokenSource) TokenSource {
+	return &interleaved{ctx: ctx, sources: append([]TokenSource(nil), sources...)}
+}
+
+// MergeSorted returns a [TokenSource] merging sources whose tokens are already sorted
+// according to less into a single sorted stream, for example to combine pre-sorted
+// shard files. Equal tokens are returned in the order of their sources.
+//
+// Errors and cancellation are handled as with [Merge]. less must not be nil: otherwise
+// the sources are closed right away and the merged source reads nothing, its Err
+// method returning an [ErrConfig] error.
+func MergeSorted(ctx context.Context, less func(a, b string) bool, sources ...TokenSource) TokenSource {
+	m := &sortedMerge{ctx: ctx, heads: mergeHeap{less: less}, sources: sources}
+	if less == nil {
+		m.err = &ConfigError{Setting: "less", Reason: "is nil"}
+		m.Close()
+	}
+	return m
+}
+
+// interleaved is the [TokenSource] returned by [Merge].
+type interleaved struct {
+	ctx     context.Context
+	sources []TokenSource
+	next    int
+	text    string
+	spans   []Span
+	err     error
+}
+
+func (m *interleaved) Scan() bool {
+	if m.err != nil {
+		return false
+	}
+	for len(m.sources) > 0 {
+		if err := m.ctx.Err(); err != nil {
+			return m.fail(err)
+		}
+		if m.next >= len(m.sources) {
+			m.next = 0
+		}
+		src := m.sources[m.next]
+		if src.Scan() {
+			m.text, m.spans = src.Text(), provenanceOf(src)
+			m.next++
+			return true
+		}
+		if err := src.Err(); err != nil {
+			return m.fail(err)
+		}
+		m.sources = append(m.sources[:m.next], m.sources[m.next+1:]...)
+	}
+	return false
+}
+
+// fail stops m with err, closing the sources not read to the end.
+func (m *interleaved) fail(err error) bool {
+	m.err = err
+	m.Close()
+	return false
+}
+
+// Close closes the sources not read to the end.
+func (m *interleaved) Close() error {
+	for _, src := range m.sources {
+		closeSource(src)
+	}
+	m.sources = nil
+	return nil
+}
+
+func (m *interleaved) Text() string {
+	return m.text
+}
+
+func (m *interleaved) Err() error {
+	return m.err
+}
+
+func (m *interleaved) Provenance() []Span {
+	return m.spans
+}
+
+// sortedMerge is the [TokenSource] returned by [MergeSorted].
+type sortedMerge struct {
+	ctx     context.Context
+	heads   mergeHeap
+	sources []TokenSource
+	// last is the source the current token comes from, -1 if exhausted.
+	last    int
+	started bool
+	text    string
+	spans   []Span
+	err     error
+}
+
+func (m *sortedMerge) Scan() bool {
+	if m.err != nil {
+		return false
+	}
+	if err := m.ctx.Err(); err != nil {
+		m.err = err
+		m.Close()
+		return false
+	}
+	if !m.started {
+		m.started = true
+		for i := range m.sources {
+			if !m.push(i) {
+				return false
+			}
+		}
+	} else if m.last >= 0 && !m.push(m.last) {
+		return false
+	}
+	if m.heads.Len() == 0 {
+		m.last = -1
+		return false
+	}
+	head := heap.Pop(&m.heads).(mergeHead)
+	m.text, m.spans, m.last = head.text, head.spans, head.source
+	return true
+}
+
+// push reads the next token of the source i into the heap.
+// It returns false if the source failed.
+func (m *sortedMerge) push(i int) bool {
+	src := m.sources[i]
+	if src.Scan() {
+		heap.Push(&m.heads, mergeHead{text: src.Text(), spans: provenanceOf(src), source: i})
+		return true
+	}
+	if err := src.Err(); err != nil {
+		m.err = err
+		m.Close()
+		return false
+	}
+	return true
+}
+
+// Close closes the sources.
+func (m *sortedMerge) Close() error {
+	for _, src := range m.sources {
+		closeSource(src)
+	}
+	return nil
+}
+
+func (m *sortedMerge) Text() string {
+	return m.text
+}
+
+func (m *sortedMerge) Err() error {
+	return m.err
+}
+
+func (m *sortedMerge) Provenance() []Span {
+	return m.spans
+}
+
+// mergeHead is the next token of a source of a [sortedMerge].
+type mergeHead struct {
+	text   string
+	spans  []Span
+	source int
+}
+
+// mergeHeap implements [heap.Interface] over the heads of a [sortedMerge].
+type mergeHeap struct {
+	less  func(a, b string) bool
+	heads []mergeHead
+}
+
+func (h mergeHeap) Len() int { return len(h.heads) }
+
+func (h mergeHeap) Less(i, j int) bool {
+	a, b := h.heads[i], h.heads[j]
+	if h.less(a.text, b.text) {
+		return true
+	}
+	if h.less(b.text, a.text) {
+		return false
+	}
+	return a.source < b.source
+}
+
+func (h mergeHeap) Swap(i, j int) { h.heads[i], h.heads[j] = h.heads[j], h.heads[i] }
+
+func (h *mergeHeap) Push(x any) { h.heads = append(h.heads, x.(mergeHead)) }
+
+func (h *mergeHeap) Pop() any {
+	n := len(h.heads) - 1
+	head := h.heads[n]
+	h.heads = h.heads[:n]
+	return head
+}
@@ -1,33 +1,119 @@
 package textio
 
-import "strings"
+import (
+	"hash"
+	"regexp"
 
-// s is the string currently being read parameter is set as the [UserContext] attribute
+	"github.com/JFinlayM/textio/normalizers"
+)
+
+// s is the token currently being read.
 // Used to transform token before passing through the [FilterFunc].
 type NormalizeFunc func(s string) string
 
 // Default normalization function. It is a wrapper for the [strings.TrimSpace] function.
 func NormalizeTrimSpace(s string) string {
-	return strings.TrimSpace(s)
+	return normalizers.TrimSpace(s)
 }
 
 // This function is a wrapper for the [strings.ToUpper] function.
 func NormalizeUpper(s string) string {
-	return strings.ToUpper(s)
+	return normalizers.Upper(s)
 }
 
 // This function is a wrapper for the [strings.ToLower] function.
 func NormalizeLower(s string) string {
-	return strings.ToLower(s)
+	return normalizers.Lower(s)
+}
+
+// NormalizeNewlines converts the "\r\n" and "\r" line endings inside tokens to "\n".
+func NormalizeNewlines(s string) string {
+	return normalizers.Newlines(s)
+}
+
+// NormalizeNewlinesTo returns a [NormalizeFunc] converting the line endings inside tokens to eol.
+func NormalizeNewlinesTo(eol string) NormalizeFunc {
+	return normalizers.NewlinesTo(eol)
+}
+
+// NormalizeUnquote returns a [NormalizeFunc] removing the quote enclosing tokens and
+// turning the doubled quotes inside them into single ones, as in CSV fields.
+// See [Delimiter.SetQuote].
+func NormalizeUnquote(quote rune) NormalizeFunc {
+	return normalizers.Unquote(quote)
+}
+
+// NormalizeNFC converts tokens to the Unicode Normalization Form C.
+func NormalizeNFC(s string) string {
+	return normalizers.NFC(s)
+}
+
+// NormalizeNFD converts tokens to the Unicode Normalization Form D.
+func NormalizeNFD(s string) string {
+	return normalizers.NFD(s)
+}
+
+// NormalizeNFKC converts tokens to the Unicode Normalization Form KC.
+func NormalizeNFKC(s string) string {
+	return normalizers.NFKC(s)
+}
+
+// NormalizeStripControl returns a [NormalizeFunc] removing the control characters
+// from tokens, except the runes of keep.
+func NormalizeStripControl(keep ...rune) NormalizeFunc {
+	return normalizers.StripControl(keep...)
+}
+
+// NormalizeHash returns a [NormalizeFunc] replacing tokens with their salted digest.
+func NormalizeHash(h func() hash.Hash, salt []byte) NormalizeFunc {
+	return normalizers.Hash(h, salt)
+}
+
+// NormalizeExpandEnv replaces the ${VAR} and $VAR references of tokens with the values
+// of the environment variables.
+func NormalizeExpandEnv(s string) string {
+	return normalizers.ExpandEnv(s)
+}
+
+// NormalizeExpand returns a [NormalizeFunc] replacing the ${VAR} and $VAR references
+// of tokens with the values of vars.
+func NormalizeExpand(vars map[string]string) NormalizeFunc {
+	return normalizers.Expand(vars)
+}
+
+// NormalizeUnescape decodes the backslash escapes of tokens.
+func NormalizeUnescape(s string) string {
+	return normalizers.Unescape(s)
+}
+
+// NormalizeEscape encodes backslashes and control characters of tokens as backslash escapes.
+func NormalizeEscape(s string) string {
+	return normalizers.Escape(s)
+}
+
+// Built-in patterns of common secrets, used by [NormalizeRedact].
+var (
+	RedactAWSAccessKey = normalizers.AWSAccessKey
+	RedactBearerToken  = normalizers.BearerToken
+	RedactEmail        = normalizers.Email
+)
+
+// NormalizeRedact returns a [NormalizeFunc] replacing every match of patterns in tokens
+// with replacement.
+func NormalizeRedact(patterns []*regexp.Regexp, replacement string) NormalizeFunc {
+	return normalizers.Redact(patterns, replacement)
 }
 
 // Creates a [NormalizeFunc] function that applies the transformations given by the ns [NormalizeFunc] functions.
-// The transformations are applied in the same order as ns.
+// The transformations are applied in the same order as ns. Nil functions are ignored.
 func ChainNormalizers(ns ...NormalizeFunc) NormalizeFunc {
-	return func(s string) string {
-		for _, n := range ns {
-			s = n(s)
-		}
-		return s
+	fs := make([]func(s string) string, len(ns))
+	for i, n := range ns {
+		fs[i] = n
 	}
+	return normalizers.Chain(fs...)
 }
+
+// NormalizeFuncInfo is a variant of [NormalizeFunc] receiving the [TokenInfo] of the
+// token currently being read. It returns the normalized token.
+type NormalizeFuncInfo func(info TokenInfo) string
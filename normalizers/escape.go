@@ -0,0 +1,77 @@
+package normalizers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Unescape decodes the backslash escapes of tokens, as in Go string literals:
+// \n, \t, \r, \\, \", \', \a, \b, \f, \v, octal \ooo, \xHH, \uXXXX and \UXXXXXXXX.
+// Malformed escapes are kept as is. It is the inverse of [Escape].
+func Unescape(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	var sb strings.Builder
+	sb.Grow(len(s))
+	for len(s) > 0 {
+		if s[0] != '\\' {
+			i := strings.IndexByte(s, '\\')
+			if i < 0 {
+				i = len(s)
+			}
+			sb.WriteString(s[:i])
+			s = s[i:]
+			continue
+		}
+		quote := byte('"')
+		if len(s) > 1 && s[1] == '\'' {
+			quote = '\''
+		}
+		value, multibyte, tail, err := strconv.UnquoteChar(s, quote)
+		if err != nil {
+			sb.WriteByte('\\')
+			s = s[1:]
+			continue
+		}
+		if multibyte {
+			sb.WriteRune(value)
+		} else {
+			sb.WriteByte(byte(value))
+		}
+		s = tail
+	}
+	return sb.String()
+}
+
+// Escape encodes backslashes and control characters of tokens as backslash
+// escapes, and invalid UTF-8 bytes as \xHH, so tokens holding such characters can be
+// written one per line and decoded back with [Unescape].
+func Escape(s string) string {
+	var sb strings.Builder
+	sb.Grow(len(s))
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case r == utf8.RuneError && size == 1:
+			fmt.Fprintf(&sb, `\x%02x`, s[i])
+		case r == '\\':
+			sb.WriteString(`\\`)
+		case r == '\n':
+			sb.WriteString(`\n`)
+		case r == '\t':
+			sb.WriteString(`\t`)
+		case r == '\r':
+			sb.WriteString(`\r`)
+		case unicode.IsControl(r):
+			fmt.Fprintf(&sb, `\u%04x`, r)
+		default:
+			sb.WriteString(s[i : i+size])
+		}
+		i += size
+	}
+	return sb.String()
+}
@@ -0,0 +1,144 @@
+// Package normalizers is the library of token normalizers, for programs that only need
+// to normalize tokens. The normalizers are plain func(string) string values, which can
+// be given as such to the Reader.SetNormalizer method of package
+// [github.com/JFinlayM/textio].
+//
+// Package normalizers does not depend on package textio, so the library can grow
+// without bloating the core. Package textio re-exports these normalizers as its
+// NormalizeXxx functions.
+package normalizers
+
+import (
+	"encoding/hex"
+	"hash"
+	"os"
+	"slices"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// TrimSpace removes the leading and trailing white space of tokens.
+// It is a wrapper for the [strings.TrimSpace] function.
+func TrimSpace(s string) string {
+	return strings.TrimSpace(s)
+}
+
+// Upper is a wrapper for the [strings.ToUpper] function.
+func Upper(s string) string {
+	return strings.ToUpper(s)
+}
+
+// Lower is a wrapper for the [strings.ToLower] function.
+func Lower(s string) string {
+	return strings.ToLower(s)
+}
+
+// Newlines converts the "\r\n" and "\r" line endings inside tokens to "\n",
+// for tokens spanning several lines (quoted fields, captured blocks...).
+func Newlines(s string) string {
+	return NewlinesTo("\n")(s)
+}
+
+// NewlinesTo returns a normalizer converting the "\r\n", "\r" and "\n"
+// line endings inside tokens to eol, such as "\r\n" for Windows consumers.
+func NewlinesTo(eol string) func(s string) string {
+	replacer := strings.NewReplacer("\r\n", eol, "\r", eol, "\n", eol)
+	return func(s string) string {
+		if !strings.ContainsAny(s, "\r\n") {
+			return s
+		}
+		return replacer.Replace(s)
+	}
+}
+
+// Unquote returns a normalizer removing the quote enclosing tokens and
+// turning the doubled quotes inside them into single ones, as in CSV fields.
+// Tokens not enclosed by quote are left unchanged.
+func Unquote(quote rune) func(s string) string {
+	q := string(quote)
+	return func(s string) string {
+		if len(s) < 2*len(q) || !strings.HasPrefix(s, q) || !strings.HasSuffix(s, q) {
+			return s
+		}
+		return strings.ReplaceAll(s[len(q):len(s)-len(q)], q+q, q)
+	}
+}
+
+// NFC converts tokens to the Unicode Normalization Form C (canonical composition),
+// so tokens with composed and decomposed characters, such as "é" written as U+00E9 or as
+// "e" followed by U+0301, compare equal. It is the form most text is stored in.
+func NFC(s string) string {
+	return norm.NFC.String(s)
+}
+
+// NFD converts tokens to the Unicode Normalization Form D (canonical decomposition).
+func NFD(s string) string {
+	return norm.NFD.String(s)
+}
+
+// NFKC converts tokens to the Unicode Normalization Form KC (compatibility
+// composition), which also folds compatibility characters such as ligatures ("ﬁ" to
+// "fi") and full-width forms, for matching rather than display.
+func NFKC(s string) string {
+	return norm.NFKC.String(s)
+}
+
+// StripControl returns a normalizer removing the C0 and C1 control
+// characters (including DEL) from tokens, except the runes of keep such as '\t',
+// so tokens are safe to log and render in terminals and web UIs.
+func StripControl(keep ...rune) func(s string) string {
+	return func(s string) string {
+		return strings.Map(func(r rune) rune {
+			if unicode.IsControl(r) && !slices.Contains(keep, r) {
+				return -1
+			}
+			return r
+		}, s)
+	}
+}
+
+// Hash returns a normalizer replacing tokens with the hex digest of salt
+// followed by the token, computed with a hash created by h (such as [crypto/sha256.New]).
+// Equal tokens keep equal digests, which allows privacy-preserving frequency analysis
+// over user-identifying tokens. The returned function is safe for concurrent use.
+func Hash(h func() hash.Hash, salt []byte) func(s string) string {
+	salt = slices.Clone(salt)
+	return func(s string) string {
+		d := h()
+		d.Write(salt)
+		d.Write([]byte(s))
+		return hex.EncodeToString(d.Sum(nil))
+	}
+}
+
+// ExpandEnv replaces the ${VAR} and $VAR references of tokens with the values
+// of the environment variables, see [os.ExpandEnv]. Undefined variables expand to "".
+func ExpandEnv(s string) string {
+	return os.ExpandEnv(s)
+}
+
+// Expand returns a normalizer replacing the ${VAR} and $VAR references
+// of tokens with the values of vars, with the semantics of [os.Expand].
+// Variables missing from vars expand to "".
+func Expand(vars map[string]string) func(s string) string {
+	return func(s string) string {
+		return os.Expand(s, func(name string) string {
+			return vars[name]
+		})
+	}
+}
+
+// Chain returns a normalizer applying the transformations of ns.
+// The transformations are applied in the same order as ns. Nil functions are ignored.
+func Chain(ns ...func(s string) string) func(s string) string {
+	return func(s string) string {
+		for _, n := range ns {
+			if n != nil {
+				s = n(s)
+			}
+		}
+		return s
+	}
+}
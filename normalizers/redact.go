@@ -0,0 +1,31 @@
+package normalizers
+
+import "regexp"
+
+// Built-in patterns of common secrets, used by [Redact].
+var (
+	// AWSAccessKey matches AWS access key IDs.
+	AWSAccessKey = regexp.MustCompile(`\b(?:AKIA|ASIA|AGPA|AIDA|AROA|ANPA|ANVA|AIPA)[0-9A-Z]{16}\b`)
+	// BearerToken matches bearer tokens of HTTP Authorization headers.
+	BearerToken = regexp.MustCompile(`(?i)\bbearer\s+[A-Za-z0-9\-._~+/]+=*`)
+	// Email matches email addresses.
+	Email = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+)
+
+// Redact returns a normalizer replacing every match of patterns in tokens
+// with replacement, so log-processing pipelines can mask sensitive data as they read it.
+// If patterns is empty, the built-in [AWSAccessKey], [BearerToken] and
+// [Email] patterns are used.
+//
+// The replacement is inserted literally, see [regexp.Regexp.ReplaceAllLiteralString].
+func Redact(patterns []*regexp.Regexp, replacement string) func(s string) string {
+	if len(patterns) == 0 {
+		patterns = []*regexp.Regexp{AWSAccessKey, BearerToken, Email}
+	}
+	return func(s string) string {
+		for _, re := range patterns {
+			s = re.ReplaceAllLiteralString(s, replacement)
+		}
+		return s
+	}
+}
@@ -0,0 +1,70 @@
+package textio
+
+// ReadOption overrides a setting of a [Reader] for a single call
+// to [Reader.ReadTokens] or [Reader.StreamTokens].
+//
+// Options are applied to a shallow copy of the [Reader], so the
+// shared configuration is never mutated and concurrent calls using
+// different options do not interfere with each other.
+type ReadOption func(*Reader)
+
+// WithLimit returns a [ReadOption] that stops reading once n tokens
+// have been accepted. A value of 0 or less disables the limit.
+func WithLimit(n int) ReadOption {
+	return func(r *Reader) {
+		r.limit = n
+	}
+}
+
+// WithFilter returns a [ReadOption] that replaces the filter function
+// of the [Reader] for the call. A nil f disables filtering.
+func WithFilter(f FilterFunc) ReadOption {
+	return func(r *Reader) {
+		r.SetFilter(f)
+	}
+}
+
+// WithNormalizer returns a [ReadOption] that replaces the normalization
+// function of the [Reader] for the call. A nil n disables normalization.
+func WithNormalizer(n NormalizeFunc) ReadOption {
+	return func(r *Reader) {
+		r.SetNormalizer(n)
+	}
+}
+
+// WithDelimiter returns a [ReadOption] that replaces the delimiter
+// of the [Reader] for the call.
+func WithDelimiter(d *Delimiter) ReadOption {
+	return func(r *Reader) {
+		r.SetDelimiter(d)
+	}
+}
+
+// WithFailOnInvalid returns a [ReadOption] that overrides the
+// [Reader.FailOnInvalid] field for the call.
+func WithFailOnInvalid(fail bool) ReadOption {
+	return func(r *Reader) {
+		r.FailOnInvalid = fail
+	}
+}
+
+// WithFailOnError returns a [ReadOption] that overrides the
+// [Reader.FailOnError] field for the call.
+func WithFailOnError(fail bool) ReadOption {
+	return func(r *Reader) {
+		r.FailOnError = fail
+	}
+}
+
+// apply returns r itself when opts is empty, otherwise a shallow copy
+// of r with every option applied in order.
+func (r *Reader) apply(opts []ReadOption) *Reader {
+	if len(opts) == 0 {
+		return r
+	}
+	newR := *r
+	for _, opt := range opts {
+		opt(&newR)
+	}
+	return &newR
+}
@@ -0,0 +1,102 @@
+package textio
+
+import (
+	"context"
+	"io"
+	"os"
+	"sync"
+)
+
+// ReadFilesParallel reads the files at paths with up to workers files tokenized
+// concurrently, using the configuration of r. It is much faster than reading the
+// files one after the other when a corpus is made of many files.
+//
+// The tokens are returned with their [TokenInfo], naming the file they come from.
+// The tokens of each file keep their order, and files are returned in the order of
+// paths. A value of workers lower than 1 reads a single file at a time.
+//
+// Each file is read on its own, as if by a copy of r: the limits of [Reader.SetLimit],
+// [Reader.SetMaxTokens] and [Reader.SetMaxBytes] apply to each file separately, and
+// the indexes of the tokens start at 0 in each file. The normalizers, filters and
+// other functions of r are shared by the workers, and so are called concurrently:
+// they must be safe for concurrent use, as are the built-in ones, including the
+// Dedup and NotSeenBloom filters of package filters.
+//
+// The first error, such as an [ErrOpen] for a missing file, cancels the remaining
+// reads and is returned with no tokens. If ctx is done, ctx.Err() is returned.
+func (r *Reader) ReadFilesParallel(ctx context.Context, paths []string, workers int) ([]TokenInfo, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make([][]TokenInfo, len(paths))
+	errs := make([]error, len(paths))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range max(workers, 1) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i], errs[i] = r.readFile(ctx, paths[i])
+				if errs[i] != nil {
+					cancel()
+				}
+			}
+		}()
+	}
+
+feed:
+	for i := range paths {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil && err != context.Canceled {
+			return nil, err
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	var all []TokenInfo
+	for _, infos := range results {
+		all = append(all, infos...)
+	}
+	return all, nil
+}
+
+// readFile reads the tokens of the file at path with the configuration of r.
+func (r *Reader) readFile(ctx context.Context, path string) ([]TokenInfo, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, newErrOpen(err)
+	}
+	defer f.Close()
+
+	fr := r.clone()
+	fr.setReaders(f)
+	it := fr.iter()
+	defer it.close()
+	var infos []TokenInfo
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		token, err := it.next()
+		if err == io.EOF {
+			return infos, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		info := it.info(it.raw, it.index-1)
+		info.Text = token
+		infos = append(infos, info)
+	}
+}
@@ -0,0 +1,99 @@
+package textio
+
+import (
+	"io"
+	"strconv"
+	"time"
+)
+
+// ReadInts reads the tokens of r as base 10 integers, see [strconv.Atoi].
+//
+// A token that fails to parse stops the read with [ErrInvalid]; the returned
+// [ReaderError] holds the token, its index among the tokens read, and the
+// [strconv.NumError]. The values parsed before a failure are returned with the error.
+func (r *Reader) ReadInts(opts ...ReadOption) ([]int, error) {
+	return ReadAs(r, ParseInt, opts...)
+}
+
+// ReadFloats reads the tokens of r as 64-bit floating point numbers, see [strconv.ParseFloat].
+// Errors are reported as with [Reader.ReadInts].
+func (r *Reader) ReadFloats(opts ...ReadOption) ([]float64, error) {
+	return ReadAs(r, ParseFloat, opts...)
+}
+
+// ReadBools reads the tokens of r as booleans, see [strconv.ParseBool].
+// Errors are reported as with [Reader.ReadInts].
+func (r *Reader) ReadBools(opts ...ReadOption) ([]bool, error) {
+	return ReadAs(r, strconv.ParseBool, opts...)
+}
+
+// ReadTimes reads the tokens of r as times formatted according to layout, see
+// [time.ParseInLocation]. Times without time zone information are interpreted in
+// loc, or in UTC if loc is nil.
+//
+// Errors are reported as with [Reader.ReadInts], with a [time.ParseError].
+// The [ReaderError] also holds the source and offset of the token in the input.
+func (r *Reader) ReadTimes(layout string, loc *time.Location, opts ...ReadOption) ([]time.Time, error) {
+	return ReadAs(r, ParseTime(layout, loc), opts...)
+}
+
+// ReadAs reads the tokens of r converted with parse, for input of any type without a
+// second conversion loop, for example:
+//
+//	ports, err := textio.ReadAs(r, func(s string) (uint16, error) {
+//		n, err := strconv.ParseUint(s, 10, 16)
+//		return uint16(n), err
+//	})
+//
+// [ParseInt], [ParseFloat] and [ParseTime] are parsers for the common types.
+// Errors are reported as with [Reader.ReadInts], with the error returned by parse.
+// The [ReaderError] also holds the source and offset of the token in the input.
+func ReadAs[T any](r *Reader, parse func(string) (T, error), opts ...ReadOption) ([]T, error) {
+	var values []T
+	it := r.apply(opts).iter()
+	defer it.close()
+	for index := 0; ; index++ {
+		token, err := it.next()
+		if err == io.EOF {
+			return values, nil
+		}
+		if err != nil {
+			return values, err
+		}
+		v, err := parse(token)
+		if err != nil {
+			return values, it.decodeError(token, index, err)
+		}
+		values = append(values, v)
+	}
+}
+
+// decodeError returns the [ErrInvalid] error of token failing to be decoded,
+// located at the position of the last scanned token.
+func (it *tokenIter) decodeError(token string, index int, err error) error {
+	re := newErrDecode(token, index, err).(*ReaderError)
+	re.Source, re.Offset = it.position()
+	return re
+}
+
+// ParseInt parses s as a base 10 integer, see [strconv.Atoi] and [ReadAs].
+func ParseInt(s string) (int, error) {
+	return strconv.Atoi(s)
+}
+
+// ParseFloat parses s as a 64-bit floating point number, see [strconv.ParseFloat] and [ReadAs].
+func ParseFloat(s string) (float64, error) {
+	return strconv.ParseFloat(s, 64)
+}
+
+// ParseTime returns a parser of times formatted according to layout, see
+// [time.ParseInLocation] and [ReadAs]. Times without time zone information
+// are interpreted in loc, or in UTC if loc is nil.
+func ParseTime(layout string, loc *time.Location) func(string) (time.Time, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+	return func(s string) (time.Time, error) {
+		return time.ParseInLocation(layout, s, loc)
+	}
+}
@@ -0,0 +1,48 @@
+package textio
+
+import "github.com/JFinlayM/textio/writers"
+
+// kindSource is implemented by the sources knowing the [TokenKind]
+// of their tokens, such as the one returned by [Reader.Source].
+type kindSource interface {
+	Kind() TokenKind
+}
+
+// Pipe writes every token of src to dst, then flushes dst.
+// It returns the number of tokens written and the first error of src or dst.
+//
+// Tokens of sources returned by [Reader.Source] keep their [TokenKind],
+// the tokens of other sources are written as [KindContent].
+func Pipe(dst TokenWriter, src TokenSource) (int, error) {
+	ks, _ := src.(kindSource)
+	n := 0
+	for src.Scan() {
+		tok := Token{Text: src.Text()}
+		if ks != nil {
+			tok.Kind = ks.Kind()
+		}
+		if err := dst.WriteToken(tok); err != nil {
+			closeSource(src)
+			return n, err
+		}
+		n++
+	}
+	if err := src.Err(); err != nil {
+		dst.Flush()
+		return n, err
+	}
+	return n, dst.Flush()
+}
+
+// Tee returns a [TokenWriter] writing every token to all of ws, in order.
+// Writing stops at the first error.
+func Tee(ws ...TokenWriter) TokenWriter {
+	return writers.Tee(ws...)
+}
+
+// PartitionTo returns a [TokenWriter] writing the content tokens satisfying f to accepted
+// and the others to rejected. A delimiter token goes to the same writer as the
+// content token it follows. See [Partition] for the channel based variant.
+func PartitionTo(f FilterFunc, accepted, rejected TokenWriter) TokenWriter {
+	return writers.Partition(f, accepted, rejected)
+}
@@ -0,0 +1,82 @@
+package textio
+
+import (
+	"sync"
+
+	"github.com/JFinlayM/textio/filters"
+)
+
+// [ReaderPool] hands out pre-configured [Reader] values backed by a [sync.Pool].
+//
+// Every [Reader] returned by [ReaderPool.Get] has the configuration of the
+// template given to [NewReaderPool]. Readers returned to the pool with
+// [ReaderPool.Put] are reset to that configuration but keep their scan buffer,
+// so servers reading one input per request do not allocate a new [Reader]
+// and a new buffer for every request.
+//
+// A [ReaderPool] is safe for concurrent use.
+type ReaderPool struct {
+	template Reader
+	pool     sync.Pool
+}
+
+// NewReaderPool creates a [ReaderPool] whose readers are copies of template.
+//
+// The template is copied, so later changes to it do not affect the pool. Each
+// [Reader] of the pool has its own input and [Stats]. A filter set on the template
+// with [Reader.SetStatefulFilter] is copied for each [Reader] when it has a
+// Clone() StatefulFilter method, or is a [filters.Dedup]; other stateful filters
+// are shared by the readers of the pool.
+func NewReaderPool(template *Reader) *ReaderPool {
+	p := &ReaderPool{template: *template.clone()}
+	p.pool.New = func() any {
+		r := &Reader{buf: make([]byte, 0, p.template.MaxTokenSize)}
+		p.reset(r)
+		return r
+	}
+	return p
+}
+
+// Get returns a [Reader] configured as the template of the pool.
+//
+// The caller typically sets the input with [Reader.SetReaders] before
+// reading, and gives the [Reader] back with [ReaderPool.Put] once done.
+func (p *ReaderPool) Get() *Reader {
+	return p.pool.Get().(*Reader)
+}
+
+// Put resets r to the configuration of the template and returns it to the pool.
+//
+// r must not be used after calling Put.
+func (p *ReaderPool) Put(r *Reader) {
+	if r == nil {
+		return
+	}
+	p.reset(r)
+	p.pool.Put(r)
+}
+
+// reset sets r to the configuration of the template, keeping its scan buffer,
+// with an input and a stateful filter of its own.
+func (p *ReaderPool) reset(r *Reader) {
+	buf := r.buf
+	*r = *p.template.clone()
+	r.buf = buf[:0]
+	if f := p.template.stateful; f != nil {
+		r.SetStatefulFilter(cloneFilter(f))
+	}
+	if r.source == nil {
+		r.setReaders(r.sources...)
+	}
+}
+
+// cloneFilter returns a copy of f, or f itself if it cannot be copied.
+func cloneFilter(f StatefulFilter) StatefulFilter {
+	switch f := f.(type) {
+	case interface{ Clone() StatefulFilter }:
+		return f.Clone()
+	case *filters.Dedup:
+		return f.Clone()
+	}
+	return f
+}
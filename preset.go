@@ -0,0 +1,46 @@
+package textio
+
+import "github.com/JFinlayM/textio/delimiters"
+
+// RegisterPreset registers d under name, replacing any preset of the same name,
+// so applications can select their tokenization by name from configuration files.
+// Later changes to d do not affect the registered preset.
+// It is safe for concurrent use.
+func RegisterPreset(name string, d *Delimiter) {
+	delimiters.Register(name, d)
+}
+
+// Preset returns a copy of the [Delimiter] registered under name, which can be
+// modified freely. The "lines", "words", "csv", "tsv", "paragraphs" and "null"
+// presets are built in, see [LinesPreset], [WordsPreset], [CSVPreset], [TSVPreset],
+// [ParagraphsPreset] and [NullDelimiter].
+func Preset(name string) (*Delimiter, bool) {
+	return delimiters.ByName(name)
+}
+
+// LinesPreset returns a [Delimiter] splitting the input into lines like [bufio.ScanLines],
+// without the "\n\n" stop pattern of the [DefaultDelimiter].
+func LinesPreset() *Delimiter {
+	return delimiters.Lines()
+}
+
+// CSVPreset returns a [Delimiter] splitting comma-separated values into fields: tokens
+// are separated by commas and line endings, except inside double quotes (see
+// [Delimiter.SetQuote]), and joined back with commas. Fields keep their quotes,
+// see [NormalizeUnquote]. There is no stop pattern.
+func CSVPreset() *Delimiter {
+	return delimiters.CSV()
+}
+
+// TSVPreset returns a [Delimiter] splitting tab-separated values into fields like
+// [CSVPreset], with tabs instead of commas.
+func TSVPreset() *Delimiter {
+	return delimiters.TSV()
+}
+
+// ParagraphsPreset returns a [Delimiter] splitting the input into paragraphs, separated
+// by one or more blank lines, and joined back with a single blank line. Leading blank
+// lines are skipped. There is no stop pattern.
+func ParagraphsPreset() *Delimiter {
+	return delimiters.Paragraphs()
+}
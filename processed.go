@@ -0,0 +1,43 @@
+package textio
+
+import "io"
+
+// Processed returns the tokens of r, once normalized and filtered, as a plain
+// [io.ReadCloser], so textio can be put in front of any API accepting an [io.Reader].
+//
+// Tokens are joined back as a [Writer] configured with the delimiter of r would do:
+// the joiner of the delimiter (see [Delimiter.SetJoiner] and [Join]) separates them,
+// content tokens are quoted if the delimiter has a quote (see [Delimiter.SetQuote]),
+// and delimiters emitted with [Delimiter.SetEmitDelimiters] are reproduced as is.
+// Tokens are made available as soon as they are read.
+//
+// Read errors are returned by Read once the tokens before the failure have been read.
+// Close stops reading; the input must not be used afterwards.
+func (r *Reader) Processed(opts ...ReadOption) io.ReadCloser {
+	r = r.apply(opts)
+	pr, pw := io.Pipe()
+	go func() {
+		w := NewWriter(pw)
+		w.SetDelimiter(r.delimiter)
+		it := r.iter()
+		defer it.close()
+		for {
+			token, err := it.next()
+			if err == io.EOF {
+				pw.Close()
+				return
+			}
+			if err == nil {
+				err = w.WriteToken(Token{Text: token, Kind: it.kind})
+			}
+			if err == nil {
+				err = w.Flush()
+			}
+			if err != nil {
+				pw.CloseWithError(err)
+				return
+			}
+		}
+	}()
+	return pr
+}
@@ -0,0 +1,105 @@
+package textio
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// Prompt asks a question on the command line and reads the answer, asking again
+// while the answer is invalid, see [Prompt.Ask]:
+//
+//	name, err := textio.Prompt{Question: "Name", Default: "guest", Retries: 2}.Ask()
+type Prompt struct {
+	// Question is written before reading each answer, followed by the
+	// default answer in brackets, if any, and ": ".
+	Question string
+	// Default is the answer used when the answer read is empty.
+	Default string
+	// Retries is how many times the question is asked again after an invalid answer.
+	Retries int
+	// Reader normalizes and filters the answers, read from its input. If nil,
+	// answers are read from [os.Stdin] with the configuration of [NewReader].
+	Reader *Reader
+	// Out is where the question and the invalid answers are written, [os.Stdout] if nil.
+	Out io.Writer
+}
+
+// Ask writes the question, reads one line as the answer, and returns it once normalized,
+// or the default answer if it is empty. An answer rejected by the filter of the [Reader]
+// is reported and the question asked again, up to Retries times.
+//
+// Answers are read one byte at a time, so that the input of the [Reader] is not read
+// past the answer and several prompts can read the same input in turn. The delimiter
+// of the [Reader] is not used.
+//
+// The last invalid answer is reported with [ErrInvalid], and an input ending before an
+// answer with [ErrRead] wrapping [io.EOF]. Errors writing the question are returned as is.
+func (p Prompt) Ask() (string, error) {
+	r, out := p.Reader, p.Out
+	if r == nil {
+		r = NewReader()
+		r.SetReaders(os.Stdin)
+	}
+	if out == nil {
+		out = os.Stdout
+	}
+	question := p.Question
+	if p.Default != "" {
+		question += " [" + p.Default + "]"
+	}
+	question += ": "
+
+	for attempt := 0; ; attempt++ {
+		if _, err := io.WriteString(out, question); err != nil {
+			return "", err
+		}
+		line, err := readLine(r.reader)
+		if err != nil {
+			return "", newErrRead(err)
+		}
+		info := TokenInfo{Text: line, Raw: line, Index: attempt, Offset: -1}
+		answer := line
+		if r.normalize != nil {
+			answer = r.normalize(line)
+		} else if r.normalizeInfo != nil {
+			answer = r.normalizeInfo(info)
+		}
+		if answer == "" {
+			answer = p.Default
+		}
+		info.Text = answer
+		if r.accept(answer, info) {
+			return answer, nil
+		}
+		if attempt >= p.Retries {
+			return "", newErrInvalid(answer, attempt)
+		}
+		if _, err := fmt.Fprintf(out, "invalid answer %q\n", answer); err != nil {
+			return "", err
+		}
+	}
+}
+
+// readLine reads one line from rd, without its line ending, reading one byte at a time.
+// It returns the last line even if it has no line ending, and [io.EOF] at the end of rd.
+func readLine(rd io.Reader) (string, error) {
+	var sb strings.Builder
+	var b [1]byte
+	for {
+		n, err := rd.Read(b[:])
+		if n > 0 {
+			if b[0] == '\n' {
+				return strings.TrimSuffix(sb.String(), "\r"), nil
+			}
+			sb.WriteByte(b[0])
+		}
+		if err == io.EOF && sb.Len() > 0 {
+			return strings.TrimSuffix(sb.String(), "\r"), nil
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package textio
+
+// Span locates a token in the original input of a pipeline.
+type Span struct {
+	// Source is the name of the source, or an empty string if the source has no name.
+	Source string
+	// Offset is the byte offset of the token within its source.
+	Offset int64
+	// Length is the length of the token in bytes, as found in the source.
+	Length int
+}
+
+// ProvenanceSource is implemented by the [TokenSource] types able to trace their current
+// token back to the original input, through chained Readers (see [Reader.SetSource])
+// and merges. The sources returned by [Reader.Source], [Merge] and [MergeSorted]
+// implement it.
+type ProvenanceSource interface {
+	TokenSource
+	// Provenance returns the spans of the input the current token was derived from,
+	// usually one, or nil if they are unknown.
+	Provenance() []Span
+}
+
+// provenanceOf returns the provenance of the current token of src, or nil.
+func provenanceOf(src TokenSource) []Span {
+	if ps, ok := src.(ProvenanceSource); ok {
+		return ps.Provenance()
+	}
+	return nil
+}
+
+// Provenance returns the spans of the input the last token read comes from. When the
+// [Reader] reads from another [ProvenanceSource], such as the source of another Reader,
+// its provenance is passed on, so tokens can be traced back through whole pipelines.
+func (s *readerSource) Provenance() []Span {
+	if s.done {
+		return nil
+	}
+	if spans := provenanceOf(s.it.r.source); spans != nil {
+		return spans
+	}
+	source, offset := s.it.position()
+	return []Span{{Source: source, Offset: offset, Length: len(s.it.raw)}}
+}
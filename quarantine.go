@@ -0,0 +1,40 @@
+package textio
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// QuarantineTo makes the [Reader] write every token rejected by the filter to w, so
+// data-cleaning jobs keep an audit trail of the input they drop. Each token is written
+// as a JSON object on its own line, holding the token, the error, the index of the token
+// among all the tokens read and its position in the input (see [TokenInfo]):
+//
+//	{"token":"n/a","error":"textio: invalid token","index":3,"source":"data.csv","offset":42}
+//
+// Tokens are written whatever [Reader.FailOnInvalid] and [Reader.CollectErrors].
+// A failure to write to w fails the read with [ErrWrite]. A nil w disables the quarantine.
+func (r *Reader) QuarantineTo(w io.Writer) {
+	r.quarantine = w
+}
+
+// quarantined is a line written by [Reader.QuarantineTo].
+type quarantined struct {
+	Token  string `json:"token"`
+	Error  string `json:"error"`
+	Index  int    `json:"index"`
+	Source string `json:"source,omitempty"`
+	Offset int64  `json:"offset"`
+}
+
+// quarantineToken writes the rejected token at index to the quarantine of the [Reader].
+func (it *tokenIter) quarantineToken(token string, index int) error {
+	q := quarantined{Token: token, Error: ErrInvalid.Error(), Index: index}
+	q.Source, q.Offset = it.position()
+	enc := json.NewEncoder(it.r.quarantine)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(q); err != nil {
+		return newErrWrite(token, index, err)
+	}
+	return nil
+}
@@ -17,65 +17,103 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"errors"
 	"io"
 	"os"
+	"slices"
 	"strings"
-)
-
-// TokenReader defines the minimal contract for reading tokens
-// from an input source in a batch-oriented manner.
-//
-// Implementations return all available tokens at once and may
-// apply tokenization, normalization, and validation logic
-// according to their configuration.
-type TokenReader interface {
-	// ReadTokens reads and returns all available tokens from the input source.
-	//
-	// The returned slice contains the tokens in the order they were read.
-	// An error is returned if tokenization, validation, or reading fails.
-	ReadTokens() ([]string, error)
-}
+	"sync"
+	"time"
 
-// TokenStreamer defines the contract for streaming tokens
-// from an input source to a channel.
-//
-// Implementations must respect context cancellation and stop
-// streaming immediately when the context is done.
-type TokenStreamer interface {
-	// StreamTokens streams tokens from the input source to the provided channel.
-	//
-	// Tokens are sent sequentially to the `out` channel until the input
-	// is exhausted, an error occurs, or the provided context is canceled.
-	//
-	// If the context is canceled, StreamTokens must return immediately
-	// with ctx.Err().
-	StreamTokens(ctx context.Context, out chan string) error
-}
-
-// TokenReaderStreamer groups batch-oriented and streaming token access.
-//
-// Types implementing this interface support both full reads
-// and incremental streaming of tokens from the same input source.
-type TokenReaderStreamer interface {
-	TokenReader
-	TokenStreamer
-}
+	"github.com/JFinlayM/textio/internal/core"
+	"golang.org/x/text/encoding"
+)
 
 // [Reader] reads tokens from an io.Reader and optionally applies
 // normalization and filtering before returning them.
 //
 // [Reader] supports both batch and streaming consumption patterns.
-// The tokens read with [Reader] are either seperate with a string delimiter [delimiterStr] or a regular expression [delimiter]
+// The tokens read with [Reader] are seperated according to its [Delimiter], either with a string or a regular expression.
 type Reader struct {
 	// The reader(s) from where we read tokens
-	reader       io.Reader
+	reader io.Reader
+	// sources are the readers combined into reader, and starts their
+	// initial offsets (-1 if they cannot seek). Used by [Reader.ReadTokensWith].
+	sources      []io.Reader
+	starts       []int64
 	MaxTokenSize int
+	// MaxBufferSize is the hard cap the scan buffer may transparently grow to
+	// when a token does not fit in MaxTokenSize bytes. A value lower than or
+	// equal to MaxTokenSize disables growth, and longer tokens fail with [ErrRead].
+	MaxBufferSize int
 	// delimiter is for the seperation of the tokens and to stop scanning.
 	delimiter     *Delimiter
 	normalize     NormalizeFunc
 	filter        FilterFunc
+	normalizeInfo NormalizeFuncInfo
+	filterInfo    FilterFuncInfo
+	// stateful is the filter set with [Reader.SetStatefulFilter], if any.
+	stateful      StatefulFilter
 	FailOnError   bool
 	FailOnInvalid bool
+	// CollectErrors makes reads go on past the tokens rejected by the filter, and
+	// report them all at the end of the read, see [Reader.ReadTokens].
+	// It takes precedence over FailOnInvalid.
+	CollectErrors bool
+	// FailOnMaxBytes makes reads fail with [ErrMaxBytes] when the input is longer
+	// than the budget set with [Reader.SetMaxBytes], instead of truncating it.
+	FailOnMaxBytes bool
+	// maxBytes is the input budget of a read in bytes, 0 means no budget.
+	maxBytes int64
+	// binary is the policy applied to sources that look binary.
+	binary BinaryPolicy
+	// limit is the maximum number of accepted tokens per read, 0 means no limit.
+	limit int
+	// maxTokens is the maximum number of tokens scanned per read before
+	// failing with [ErrLimit], 0 means no maximum.
+	maxTokens int
+	// buf is a scan buffer kept across reads by a [ReaderPool].
+	buf []byte
+	// byteRange restricts reading to the tokens starting in a range of the input.
+	byteRange *byteRange
+	// source replaces the readers and the delimiter when set, see [Reader.SetSource].
+	source TokenSource
+	// schedule is the order in which sources are read, see [Reader.SetSchedule].
+	schedule Schedule
+	// emptyDefault replaces empty tokens when set, see [Reader.SetEmptyDefault].
+	emptyDefault *string
+	// codec decodes every source when set, see [Reader.SetCodec].
+	codec Codec
+	// charset is the encoding of the sources, see [Reader.SetEncoding].
+	charset encoding.Encoding
+	// onStall is called when [Reader.StreamTokens] is blocked for stallAfter,
+	// see [Reader.SetStallHook].
+	stallAfter time.Duration
+	onStall    func(StallInfo)
+	// onInvalid and onError are called for the rejected tokens and read
+	// errors that do not fail reads, see [Reader.OnInvalid] and [Reader.OnError].
+	onInvalid func(tok string, pos int)
+	onError   func(err error)
+	// quarantine receives the rejected tokens, see [Reader.QuarantineTo].
+	quarantine io.Writer
+	// adaptive sizes the scan buffer when set, see [Reader.SetAdaptiveBuffer].
+	adaptive *tokenSizeEstimate
+	// fields splits the records into fields, see [Reader.SetFieldDelimiter].
+	fields *Delimiter
+	// mapper rewrites the normalized tokens, see [Reader.SetMapper].
+	mapper MapFunc
+	// lowAlloc carves tokens out of shared arenas, see [Reader.SetLowAlloc].
+	lowAlloc bool
+	// continuation is the line continuation marker, see [Reader.SetContinuation].
+	continuation string
+	// detectLang finds the language of tokens, see [Reader.SetLanguageDetector].
+	detectLang func(string) string
+}
+
+// byteRange restricts a read to the tokens starting in [begin, end).
+// The bytes before begin are only read to find where the first token starts.
+type byteRange struct {
+	begin, end int64
 }
 
 // NewReader creates a new Reader with default configuration.
@@ -87,8 +125,11 @@ type Reader struct {
 // The returned Reader can be further configured using the
 // provided setter methods before reading.
 func NewReader() *Reader {
+	sources := []io.Reader{os.Stdin}
 	return &Reader{
-		reader:       os.Stdin,
+		reader:       newMultiSource(sources),
+		sources:      sources,
+		starts:       []int64{-1},
 		delimiter:    DefaultDelimiter(),
 		normalize:    NormalizeTrimSpace,
 		FailOnError:  true,
@@ -96,15 +137,32 @@ func NewReader() *Reader {
 	}
 }
 
+// clone returns a shallow copy of r that does not share its scan buffer.
+func (r *Reader) clone() *Reader {
+	newR := *r
+	newR.buf = nil
+	return &newR
+}
+
 // [FromString] returns a shallow copy of the [Reader]
 // with a new reader from string s.
 //
 // The original [Reader] is not modified.
 func (r *Reader) FromString(s string) *Reader {
 	strReader := strings.NewReader(s)
-	newR := *r
+	newR := r.clone()
 	newR.SetReaders(strReader)
-	return &newR
+	return newR
+}
+
+// [FromSource] returns a shallow copy of the [Reader]
+// reading its tokens from src, see [Reader.SetSource].
+//
+// The original [Reader] is not modified.
+func (r *Reader) FromSource(src TokenSource) *Reader {
+	newR := r.clone()
+	newR.SetSource(src)
+	return newR
 }
 
 // [FromBytes] returns a shallow copy of the [Reader]
@@ -113,9 +171,26 @@ func (r *Reader) FromString(s string) *Reader {
 // The original [Reader] is not modified.
 func (r *Reader) FromBytes(b []byte) *Reader {
 	bytesReader := bytes.NewReader(b)
-	newR := *r
+	newR := r.clone()
 	newR.SetReaders(bytesReader)
-	return &newR
+	return newR
+}
+
+// [FromFile] returns a shallow copy of the [Reader]
+// with a new reader from the file at path.
+//
+// The file is opened when the first token is read, and closed once read to the end,
+// so there is nothing to close; a file that cannot be opened is reported like other
+// read errors (see [ErrRead]). A read stopping before the end of the file, such as
+// with a limit, leaves the file open until it is garbage collected: use
+// [ReaderCloser.FromFile] to close it explicitly. Compressed files are decompressed,
+// see [WrapCompression].
+//
+// The original [Reader] is not modified.
+func (r *Reader) FromFile(path string) *Reader {
+	newR := r.clone()
+	newR.SetReaders(&lazyFile{path: path})
+	return newR
 }
 
 // WithDelimiter returns a shallow copy of the [Reader]
@@ -123,9 +198,9 @@ func (r *Reader) FromBytes(b []byte) *Reader {
 //
 // The original [Reader] is not modified.
 func (r *Reader) WithDelimiter(d *Delimiter) *Reader {
-	newR := *r
+	newR := r.clone()
 	newR.SetDelimiter(d)
-	return &newR
+	return newR
 }
 
 // WithNormalizer returns a shallow copy of the [Reader]
@@ -134,9 +209,9 @@ func (r *Reader) WithDelimiter(d *Delimiter) *Reader {
 // The normalizer is applied to each token before filtering.
 // The original [Reader] is not modified.
 func (r *Reader) WithNormalizer(n NormalizeFunc) *Reader {
-	newR := *r
+	newR := r.clone()
 	newR.SetNormalizer(n)
-	return &newR
+	return newR
 }
 
 // WithFilter returns a shallow copy of the [Reader]
@@ -145,9 +220,9 @@ func (r *Reader) WithNormalizer(n NormalizeFunc) *Reader {
 // The filter is evaluated after normalization.
 // The original [Reader] is not modified.
 func (r *Reader) WithFilter(f FilterFunc) *Reader {
-	newR := *r
+	newR := r.clone()
 	newR.SetFilter(f)
-	return &newR
+	return newR
 }
 
 // WithReaders returns a shallow copy of the [Reader]
@@ -155,47 +230,154 @@ func (r *Reader) WithFilter(f FilterFunc) *Reader {
 //
 // The original [Reader] is not modified.
 func (r *Reader) WithReaders(readers ...io.Reader) *Reader {
-	newR := *r
+	newR := r.clone()
 	newR.SetReaders(readers...)
-	return &newR
+	return newR
 }
 
 // [SetReaders] replaces the current input source with the provided readers.
 //
-// All readers are combined into a single stream using [io.MultiReader],
+// All readers are combined into a single stream, in the manner of [io.MultiReader],
 // and are consumed sequentially in the order they are provided.
+// Readers with a Name method, such as [os.File], or wrapped with [Named]
+// give their name to the tokens read from them (see [TokenInfo]).
 //
-// Any previously configured reader is discarded.
+// Any previously configured reader is discarded, and the filter set with
+// [Reader.SetStatefulFilter] is reset.
 func (r *Reader) SetReaders(readers ...io.Reader) {
-	r.reader = io.MultiReader(readers...)
+	r.resetFilter()
+	r.setReaders(readers...)
+}
+
+// setReaders is [Reader.SetReaders] without resetting the stateful filter, for the
+// internal copies of r reading part of its input, which share the filter with r.
+func (r *Reader) setReaders(readers ...io.Reader) {
+	r.source = nil
+	r.sources = nil
+	r.starts = nil
+	r.trackSources(readers)
+	r.reader = newMultiSource(r.sources)
 }
 
 // [AddReaders] appends the provided readers to the existing input source.
 //
 // The existing reader is preserved and the new readers are appended
-// after it, forming a single sequential stream via [io.MultiReader].
+// after it, forming a single sequential stream.
 //
 // This allows additional input sources to be added without
 // replacing the current reader.
 func (r *Reader) AddReaders(readers ...io.Reader) {
-	readers = append([]io.Reader{r.reader}, readers...)
-	r.SetReaders(readers...)
+	r.trackSources(readers)
+	ms := newMultiSource(r.sources)
+	if old := r.input(); old != nil {
+		for i := range old.counts {
+			ms.counts[i].Store(old.counts[i].Load())
+		}
+		ms.stats.add(&old.stats)
+	}
+	r.reader = ms
+}
+
+// SetMaxTokens sets the maximum number of tokens a single read may produce, counting
+// the tokens rejected by the filter. Reading more tokens fails with [ErrLimit], as a
+// protection against malicious inputs with a pathological delimiter density.
+// A value of 0 or less removes the maximum. To stop reading without error after a
+// number of accepted tokens, use [Reader.SetLimit] instead.
+func (r *Reader) SetMaxTokens(n int) {
+	r.maxTokens = n
+}
+
+// SetLimit makes every read stop once n tokens have been accepted, returning the
+// tokens collected so far without error, so the work done on huge or unbounded
+// inputs (pipes, sockets) is capped. It applies to all the reading methods, such as
+// [Reader.ReadTokens] and [Reader.StreamTokens], and can be overridden for a single
+// call with [WithLimit]. A value of 0 or less removes the limit, which is the default.
+//
+// The input is not consumed past the last accepted token, and only a partial
+// buffer of it may have been read.
+func (r *Reader) SetLimit(n int) {
+	r.limit = n
+}
+
+// OnInvalid sets f to be called with each token rejected by the filter and skipped,
+// that is unless [Reader.FailOnInvalid] is set, so skipped input can be logged or
+// counted. pos is the index of the token among all the tokens read (see [TokenInfo]).
+// A nil f removes the hook.
+func (r *Reader) OnInvalid(f func(tok string, pos int)) {
+	r.onInvalid = f
+}
+
+// OnError sets f to be called with the read errors ending reads without failing them,
+// that is when [Reader.FailOnError] is not set. A nil f removes the hook.
+func (r *Reader) OnError(f func(err error)) {
+	r.onError = f
+}
+
+// SetEmptyDefault makes the [Reader] substitute s for empty tokens, such as the empty
+// fields of "a,,b" or the empty tokens kept by the [EmptyEmit] policy, so they need
+// no custom normalizer. The substitution applies to tokens that are empty after
+// normalization, before filtering.
+func (r *Reader) SetEmptyDefault(s string) {
+	r.emptyDefault = &s
+}
+
+// SetSource makes the [Reader] read its tokens from src, such as a source returned by
+// [FromChannel], instead of splitting the input of its readers with its delimiter.
+// The tokens go through the same normalization, filtering and error handling.
+//
+// Setting readers with [Reader.SetReaders] discards src, and setting
+// src discards the readers. The filter set with [Reader.SetStatefulFilter] is reset.
+func (r *Reader) SetSource(src TokenSource) {
+	r.resetFilter()
+	r.sources = nil
+	r.starts = nil
+	r.reader = newMultiSource(nil)
+	r.source = src
 }
 
 // Sets the delimiter used to seperate input into tokens.
-// This resets the [delimiterStr] field of r.
 func (r *Reader) SetDelimiter(d *Delimiter) {
 	r.delimiter = d
 }
 
+// SetMaxBytes sets the maximum number of input bytes consumed by a single read.
+//
+// Once n bytes have been read, the input is truncated: the last token holds the
+// bytes read before the budget was reached. If [Reader.FailOnMaxBytes] is set,
+// the read fails with [ErrMaxBytes] instead when the input is longer than n bytes.
+// A value of 0 or less removes the budget.
+func (r *Reader) SetMaxBytes(n int64) {
+	r.maxBytes = n
+}
+
 // Sets the function to be called to normalize current read token before passing through filter function. There is none by default.
+// This resets the function set with [Reader.SetNormalizerInfo].
 func (r *Reader) SetNormalizer(normalizeFunc NormalizeFunc) {
 	r.normalize = normalizeFunc
+	r.normalizeInfo = nil
 }
 
 // Sets the function to be called to filter current read token. Should return true is the token satisfies user defined constraints, false otherwise.
+// This resets the function set with [Reader.SetFilterInfo].
 func (r *Reader) SetFilter(filterFunc FilterFunc) {
 	r.filter = filterFunc
+	r.filterInfo = nil
+	r.stateful = nil
+}
+
+// Sets the function to be called with the [TokenInfo] of current read token to normalize it before passing through filter function.
+// This resets the function set with [Reader.SetNormalizer].
+func (r *Reader) SetNormalizerInfo(normalizeFunc NormalizeFuncInfo) {
+	r.normalizeInfo = normalizeFunc
+	r.normalize = nil
+}
+
+// Sets the function to be called with the [TokenInfo] of current read token to filter it.
+// This resets the function set with [Reader.SetFilter].
+func (r *Reader) SetFilterInfo(filterFunc FilterFuncInfo) {
+	r.filterInfo = filterFunc
+	r.filter = nil
+	r.stateful = nil
 }
 
 // Read processes input from the provided [io.Reader](s).
@@ -204,6 +386,9 @@ func (r *Reader) SetFilter(filterFunc FilterFunc) {
 // If a end delimiter is set, then the scanning stop if there is a token matching seperation delimiter followed by end delimiter.
 // For example: input = "hello\nworld\nend", returns ["hello", "world"] if delimiter = "\n" and endDelimiter = "end".
 //
+// The optional opts override the [Reader] configuration for this call only,
+// for example r.ReadTokens(WithLimit(100), WithFilter(f)). The [Reader] itself is not modified.
+//
 // Returns:
 //   - A slice of strings containing the processed input.
 //   - error: [ErrInvalid] if the token doesnt respect constraints defined by filter function and if [FailOnInvalid] is set. [ErrRead] if an error occured during scanning.
@@ -214,38 +399,96 @@ func (r *Reader) SetFilter(filterFunc FilterFunc) {
 //   - If a normalization function is provided, it applies the function to each string read.
 //   - If a filtering function is provided, it validates each string against the filter.
 //     If a string fails the filter and FailOnInvalid is true, the function returns an error. Otherwise, it skips the invalid string.
+//   - If [Reader.CollectErrors] is set, reading goes on past the invalid strings, and an [ErrInvalid] error
+//     locating each of them (see [ReaderError]) is returned at the end, joined with [errors.Join],
+//     along with the valid strings.
 //   - If an error occurs during scanning and FailOnError is true, the function returns the error.
-func (r *Reader) ReadTokens() ([]string, error) {
+//   - If a limit is set with [WithLimit], reading stops once that many tokens have been accepted.
+func (r *Reader) ReadTokens(opts ...ReadOption) ([]string, error) {
 	var tokens []string
-	scanner := bufio.NewScanner(r.reader)
-	buf := make([]byte, 0, r.MaxTokenSize)
-	scanner.Buffer(buf, r.MaxTokenSize)
-	scanner.Split(r.delimiter.SplitFunc())
-
-	n := 0
-	for scanner.Scan() {
-		token := scanner.Text()
-		if r.normalize != nil {
-			token = r.normalize(token)
+	it := r.apply(opts).iter()
+	defer it.close()
+	for {
+		token, err := it.next()
+		if err == io.EOF {
+			return tokens, nil
 		}
-
-		if r.filter != nil && !r.filter(token) {
-			if r.FailOnInvalid {
-				return tokens, newErrInvalid(token, n)
-			}
-			n += len(token)
-			continue
+		if err != nil {
+			return tokens, err
 		}
-
-		n += len(token)
 		tokens = append(tokens, token)
 	}
+}
 
-	if err := scanner.Err(); err != nil && r.FailOnError {
-		return tokens, newErrRead(err)
+// ReadTokensWith re-tokenizes the input of the [Reader] using the delimiter d
+// instead of the configured one, for example to read a source by lines and
+// then by words.
+//
+// Sources implementing [io.Seeker] (strings, byte slices, files) are rewound
+// to the offset they had when they were added, so the whole input is read
+// again. Other sources are read from their current position.
+//
+// The [Reader] configuration is not modified. Errors are the same as [Reader.ReadTokens].
+func (r *Reader) ReadTokensWith(d *Delimiter) ([]string, error) {
+	if err := r.rewind(); err != nil {
+		return nil, err
+	}
+	return r.ReadTokens(WithDelimiter(d))
+}
+
+// ReadTokensSorted is like [Reader.ReadTokens] but returns the tokens sorted according
+// to less, lexicographically if less is nil. If unique is set, tokens equal according
+// to less are only returned once.
+//
+// On error, the tokens read before the failure are returned sorted along with the error.
+func (r *Reader) ReadTokensSorted(less func(a, b string) bool, unique bool, opts ...ReadOption) ([]string, error) {
+	tokens, err := r.ReadTokens(opts...)
+	if less == nil {
+		slices.Sort(tokens)
+		if unique {
+			tokens = slices.Compact(tokens)
+		}
+		return tokens, err
+	}
+	slices.SortFunc(tokens, func(a, b string) int {
+		switch {
+		case less(a, b):
+			return -1
+		case less(b, a):
+			return 1
+		}
+		return 0
+	})
+	if unique {
+		tokens = slices.CompactFunc(tokens, func(a, b string) bool {
+			return !less(a, b) && !less(b, a)
+		})
 	}
+	return tokens, err
+}
 
-	return tokens, nil
+// ReadTagged is like [Reader.ReadTokens] but returns each token along with its
+// [TokenKind]. When the delimiter emits delimiters (see [Delimiter.SetEmitDelimiters]),
+// filters and normalization apply to content tokens only while delimiter tokens are
+// returned intact, so the original spacing can be reproduced downstream.
+func (r *Reader) ReadTagged(opts ...ReadOption) ([]Token, error) {
+	var tokens []Token
+	it := r.apply(opts).iter()
+	defer it.close()
+	for {
+		text, err := it.next()
+		if err == io.EOF {
+			return tokens, nil
+		}
+		if err != nil {
+			return tokens, err
+		}
+		token := Token{Text: text, Kind: it.kind}
+		if it.kind == KindContent {
+			token.Lang = it.lang
+		}
+		tokens = append(tokens, token)
+	}
 }
 
 // Read processes input from the provided [io.Reader](s).
@@ -288,36 +531,568 @@ func (r *Reader) Read(p []byte) (n int, err error) {
 //   - Normalization is applied before filtering.
 //   - Tokens that fail the filter are skipped unless FailOnInvalid is set.
 //   - The function terminates when all input is consumed, an error occurs, or the context is canceled.
-func (r *Reader) StreamTokens(ctx context.Context, out chan string) error {
-	scanner := bufio.NewScanner(r.reader)
+//   - The optional opts override the [Reader] configuration for this call only.
+//   - Sends blocked for too long are reported to the hook set with [Reader.SetStallHook].
+func (r *Reader) StreamTokens(ctx context.Context, out chan string, opts ...ReadOption) error {
+	it := r.apply(opts).iter()
+	defer it.close()
+	for {
+		token, err := it.next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := r.send(ctx, out, token, it.index-1); err != nil {
+			return err
+		}
+	}
+}
+
+// StreamBatches is like [Reader.StreamTokens] but sends the tokens to out in slices of
+// batchSize tokens, for consumers processing tokens in bulk such as database inserts.
+// The last batch holds the remaining tokens and may be shorter; it is also sent
+// before returning a read error. A batchSize of 0 or less sends single tokens.
+// Each batch is a new slice that the consumer may keep.
+func (r *Reader) StreamBatches(ctx context.Context, out chan []string, batchSize int, opts ...ReadOption) error {
+	batchSize = max(batchSize, 1)
+	it := r.apply(opts).iter()
+	defer it.close()
+	send := func(batch []string) error {
+		select {
+		case out <- batch:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	batch := make([]string, 0, batchSize)
+	for {
+		token, err := it.next()
+		if err != nil {
+			if len(batch) > 0 {
+				if sendErr := send(batch); sendErr != nil {
+					return sendErr
+				}
+			}
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		batch = append(batch, token)
+		if len(batch) == batchSize {
+			if err := send(batch); err != nil {
+				return err
+			}
+			batch = make([]string, 0, batchSize)
+		}
+	}
+}
+
+// StreamTo writes the tokens to w as they are produced, separated by joiner, for piping
+// filtered output to sockets or pipes. Output is buffered and flushed every flushEvery,
+// even while waiting for input, so latency stays bounded; a flushEvery of 0 or less
+// flushes after every token. Everything is flushed before returning.
+//
+// Errors are the same as [Reader.StreamTokens], and errors of w are reported with [ErrWrite].
+func (r *Reader) StreamTo(ctx context.Context, w io.Writer, joiner string, flushEvery time.Duration, opts ...ReadOption) error {
+	d := NewDelimiter()
+	d.SetJoiner(joiner)
+	tw := NewWriter(w)
+	tw.SetDelimiter(d)
+
+	var mu sync.Mutex
+	if flushEvery > 0 {
+		ticker := time.NewTicker(flushEvery)
+		done := make(chan struct{})
+		defer func() {
+			ticker.Stop()
+			close(done)
+		}()
+		go func() {
+			for {
+				select {
+				case <-ticker.C:
+					mu.Lock()
+					tw.Flush()
+					mu.Unlock()
+				case <-done:
+					return
+				}
+			}
+		}()
+	}
+
+	err := r.streamTo(ctx, func(token string) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if err := tw.WriteString(token); err != nil || flushEvery > 0 {
+			return err
+		}
+		return tw.Flush()
+	}, opts)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if flushErr := tw.Flush(); err == nil {
+		err = flushErr
+	}
+	return err
+}
+
+// streamTo calls write with every token until the input is exhausted,
+// an error occurs, or ctx is done.
+func (r *Reader) streamTo(ctx context.Context, write func(string) error, opts []ReadOption) error {
+	it := r.apply(opts).iter()
+	defer it.close()
+	for {
+		token, err := it.next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := write(token); err != nil {
+			return err
+		}
+	}
+}
+
+// StreamProgress reports how far [Reader.StreamFor] got.
+type StreamProgress struct {
+	// Tokens is the number of tokens sent.
+	Tokens int
+	// Offset is the input offset right after the last token sent.
+	Offset int64
+	// Done is set when the input was exhausted before the duration elapsed.
+	Done bool
+}
+
+// StreamFor streams tokens to out like [Reader.StreamTokens] and stops cleanly once
+// d has elapsed, for sampling live sources (tailed files, sockets) for a fixed window.
+//
+// Reaching the end of the window is not an error. The returned [StreamProgress]
+// tells how many tokens were sent and where the input stopped. A read blocked
+// at the end of the window is left to complete in the background, where the
+// input is then released, and its token is dropped, so the input must not be
+// used afterwards.
+func (r *Reader) StreamFor(d time.Duration, out chan string, opts ...ReadOption) (StreamProgress, error) {
+	type result struct {
+		token string
+		end   int64
+		err   error
+	}
+	it := r.apply(opts).iter()
+	results := make(chan result)
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		// it is closed here rather than by StreamFor, which may return while
+		// it.next is still running.
+		defer it.close()
+		for {
+			token, err := it.next()
+			select {
+			case results <- result{token, it.start + int64(len(it.raw)), err}:
+			case <-stop:
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	var p StreamProgress
+	for {
+		select {
+		case res := <-results:
+			if res.err == io.EOF {
+				p.Done = true
+				return p, nil
+			}
+			if res.err != nil {
+				return p, res.err
+			}
+			select {
+			case out <- res.token:
+				p.Tokens++
+				p.Offset = res.end
+			case <-timer.C:
+				return p, nil
+			}
+		case <-timer.C:
+			return p, nil
+		}
+	}
+}
+
+// newScanner returns a [bufio.Scanner] reading from the input source of r,
+// sized and split according to the configuration of r.
+func (r *Reader) newScanner() *bufio.Scanner {
+	src := r.reader
+	if ms, ok := src.(*multiSource); ok {
+		ms.binary = r.binary
+		ms.codec = r.codec
+		ms.charset = r.charset
+	}
+	if r.maxBytes > 0 {
+		src = &budgetReader{r: src, limit: r.maxBytes, remaining: r.maxBytes, fail: r.FailOnMaxBytes}
+	}
+	scanner := bufio.NewScanner(src)
+	size := r.MaxTokenSize
+	if r.adaptive != nil {
+		size = r.adaptive.bufferSize(r.MaxTokenSize)
+	}
+	buf := r.buf[:0]
+	if cap(buf) < size {
+		buf = make([]byte, 0, size)
+	}
+	scanner.Buffer(buf, max(r.MaxTokenSize, r.MaxBufferSize))
 	scanner.Split(r.delimiter.SplitFunc())
+	return scanner
+}
 
-	n := 0
-	for scanner.Scan() {
-		token := scanner.Text()
+// tokenIter walks the normalize and filter pipeline of a [Reader],
+// yielding one accepted token at a time.
+type tokenIter struct {
+	r *Reader
+	// tokens is the scanner splitting the input, or the source of the [Reader].
+	tokens TokenSource
+	// n is the running index reported in [ErrInvalid] errors.
+	n int
+	// accepted counts the tokens returned so far.
+	accepted int
+	// index is the index of the next scanned token.
+	index int
+	// pos is the offset of the scanner in the input, and start
+	// the offset of the last scanned token.
+	pos, start int64
+	// kind is the kind of the last scanned token, and raw its text
+	// as found in the input.
+	kind TokenKind
+	raw  string
+	// collected are the errors of the rejected tokens, see [Reader.CollectErrors].
+	collected []error
+	// lang is the language of the last content token, see [Reader.SetLanguageDetector].
+	lang string
+	// mapped are the tokens produced by the mapper and not yet filtered,
+	// and mappedInfo describes the token they were produced from.
+	mapped     []string
+	mappedInfo TokenInfo
+	// arena holds the text of the last tokens, see [Reader.SetLowAlloc].
+	arena []byte
+	// stats are the token counters of the input.
+	stats *tokenStats
+	// sched reads the sources when the [Reader] has a schedule.
+	sched *scheduler
+	// skipTo is the offset up to which tokens are skipped, see [Reader.StreamAck].
+	skipTo int64
+}
+
+// close releases the resources of it once the caller is done with it.
+func (it *tokenIter) close() {
+	if it.sched != nil {
+		it.sched.stop()
+	}
+}
+
+func (r *Reader) iter() *tokenIter {
+	it := &tokenIter{r: r, stats: &tokenStats{}}
+	if ms := r.input(); ms != nil {
+		it.stats = &ms.stats
+	}
+	if r.source != nil {
+		it.tokens = r.source
+		return it
+	}
+	if r.schedule.mode != scheduleSequential && len(r.sources) > 1 {
+		it.sched = newScheduler(r)
+		it.tokens = it.sched
+		return it
+	}
+	scanner := r.newScanner()
+	scanner.Split(it.track(core.SplitFunc(r.delimiter, &it.kind)))
+	it.tokens = scanner
+	return it
+}
+
+// track wraps split to keep the input offsets of it up to date.
+func (it *tokenIter) track(split bufio.SplitFunc) bufio.SplitFunc {
+	return func(data []byte, atEOF bool) (int, []byte, error) {
+		advance, token, err := split(data, atEOF)
+		if token != nil {
+			it.start = it.pos + int64(subsliceOffset(data, token))
+		}
+		it.pos += int64(advance)
+		return advance, token, err
+	}
+}
+
+// subsliceOffset returns the offset of sub within data,
+// or 0 if sub does not share the memory of data.
+func subsliceOffset(data, sub []byte) int {
+	off := cap(data) - cap(sub)
+	if off < 0 || off > len(data) {
+		return 0
+	}
+	return off
+}
+
+// info describes the last scanned token, whose raw text is raw.
+func (it *tokenIter) info(raw string, index int) TokenInfo {
+	info := TokenInfo{
+		Text:  raw,
+		Raw:   raw,
+		Index: index,
+		Lang:  it.lang,
+	}
+	info.Source, info.Offset = it.position()
+	return info
+}
+
+// position returns the name of the source of the last scanned token
+// and its offset in that source.
+func (it *tokenIter) position() (string, int64) {
+	if it.sched != nil {
+		return it.sched.item.source, it.sched.item.offset
+	}
+	if it.r.source != nil {
+		if spans := provenanceOf(it.r.source); len(spans) > 0 {
+			return spans[0].Source, spans[0].Offset
+		}
+		return "", it.start
+	}
+	if ms, ok := it.r.reader.(*multiSource); ok {
+		if i, off := ms.locate(it.start); i >= 0 {
+			return sourceName(ms.readers[i]), off
+		}
+		return "", it.start
+	}
+	return sourceName(it.r.reader), it.start
+}
+
+// next returns the next accepted token.
+// It returns [io.EOF] once the input is exhausted or the limit is reached.
+func (it *tokenIter) next() (string, error) {
+	r := it.r
+	if r.limit > 0 && it.accepted >= r.limit {
+		return "", it.withCollected(io.EOF)
+	}
+	if token, ok, err := it.nextMapped(); ok || err != nil {
+		return token, err
+	}
+
+	for it.tokens.Scan() {
+		token := it.text()
+		it.raw = token
+		if r.adaptive != nil {
+			r.adaptive.observe(len(token))
+		}
+		if it.sched != nil {
+			it.kind = it.sched.item.kind
+		}
+		if r.continuation != "" && it.kind != KindDelimiter {
+			token = it.joinContinued(token)
+			it.raw = token
+		}
+		if it.skipTo > 0 && it.start+int64(len(it.raw)) <= it.skipTo {
+			continue
+		}
+
+		if rg := r.byteRange; rg != nil {
+			if it.start >= rg.end {
+				return "", io.EOF
+			}
+			if it.start < rg.begin {
+				continue
+			}
+		}
+
+		if it.kind == KindDelimiter {
+			// Delimiters are kept intact for reconstruction.
+			return token, nil
+		}
+		index := it.index
+		if r.maxTokens > 0 && index >= r.maxTokens {
+			return "", it.withCollected(newErrLimit(r.maxTokens))
+		}
+		it.index++
+		it.stats.tokens.Add(1)
+
+		if r.detectLang != nil {
+			it.lang = r.detectLang(token)
+		}
+		info := TokenInfo{Index: index}
+		if r.normalizeInfo != nil || r.filterInfo != nil {
+			info = it.info(token, index)
+		}
 
 		if r.normalize != nil {
 			token = r.normalize(token)
+		} else if r.normalizeInfo != nil {
+			token = r.normalizeInfo(info)
+		}
+		if token == "" && r.emptyDefault != nil {
+			token = *r.emptyDefault
 		}
 
-		if r.filter != nil && !r.filter(token) {
-			if r.FailOnInvalid {
-				return newErrInvalid(token, n)
+		if r.mapper != nil {
+			if tokens, ok := r.mapper(token); ok {
+				it.mapped, it.mappedInfo = tokens, info
+				if token, ok, err := it.nextMapped(); ok || err != nil {
+					return token, err
+				}
+				continue
 			}
-			n += len(token)
-			continue
 		}
 
-		n += len(token)
-		select {
-		case out <- token:
-		case <-ctx.Done():
-			return ctx.Err()
+		ok, err := it.admit(token, info)
+		if err != nil {
+			return "", err
+		}
+		if ok {
+			return token, nil
 		}
 	}
 
-	if err := scanner.Err(); err != nil && r.FailOnError {
+	return "", it.withCollected(it.readErr())
+}
+
+// admit runs the filter on token, described by info, and reports whether it is accepted.
+// It returns the error ending the read of a rejected token, if any.
+func (it *tokenIter) admit(token string, info TokenInfo) (bool, error) {
+	r := it.r
+	index := info.Index
+	info.Text = token
+	if !r.accept(token, info) {
+		it.stats.rejected.Add(1)
+		if r.quarantine != nil {
+			if err := it.quarantineToken(token, index); err != nil {
+				return false, err
+			}
+		}
+		if r.onInvalid != nil && (r.CollectErrors || !r.FailOnInvalid) {
+			r.onInvalid(token, index)
+		}
+		if r.CollectErrors {
+			re := newErrInvalid(token, it.n).(*ReaderError)
+			re.Source, re.Offset = it.position()
+			it.collected = append(it.collected, re)
+			it.n += len(token)
+			return false, nil
+		}
+		if r.FailOnInvalid {
+			return false, newErrInvalid(token, it.n)
+		}
+		it.n += len(token)
+		return false, nil
+	}
+
+	it.n += len(token)
+	it.accepted++
+	it.stats.accepted.Add(1)
+	return true, nil
+}
+
+// withCollected returns err, which ends the read, joined with the errors
+// collected so far if any, see [Reader.CollectErrors].
+func (it *tokenIter) withCollected(err error) error {
+	if len(it.collected) == 0 {
+		return err
+	}
+	if err != io.EOF {
+		it.collected = append(it.collected, err)
+	}
+	err = errors.Join(it.collected...)
+	it.collected = nil
+	return err
+}
+
+// readErr returns the error that ended the scan of the input,
+// or [io.EOF] if there is none to report.
+func (it *tokenIter) readErr() error {
+	r := it.r
+	err := it.tokens.Err()
+	var readerErr *ReaderError
+	if errors.As(err, &readerErr) {
+		// Already reported by a scheduled source.
+		return err
+	}
+	var budgetErr *BudgetExceededError
+	if errors.As(err, &budgetErr) {
+		return newErrMaxBytes(budgetErr)
+	}
+	var binErr *binarySourceError
+	if errors.As(err, &binErr) {
+		return newErrBinaryInput(binErr)
+	}
+	if errors.Is(err, ErrLeadingDelimiter) {
+		return newErrLeadingDelimiter()
+	}
+	if errors.Is(err, ErrPatternTimeout) {
+		return newErrPatternTimeout(err)
+	}
+	if err != nil && r.FailOnError {
 		return newErrRead(err)
 	}
-	return nil
+	if err != nil && r.onError != nil {
+		r.onError(err)
+	}
+	return io.EOF
+}
+
+// budgetReader reads at most remaining bytes from r. Past that budget it
+// returns [io.EOF], or a [BudgetExceededError] if fail is set and r has more data.
+type budgetReader struct {
+	r         io.Reader
+	limit     int64
+	remaining int64
+	fail      bool
+}
+
+func (b *budgetReader) Read(p []byte) (int, error) {
+	if b.remaining <= 0 {
+		if !b.fail {
+			return 0, io.EOF
+		}
+		var probe [1]byte
+		n, err := io.ReadFull(b.r, probe[:])
+		if n > 0 {
+			budgetErr := &BudgetExceededError{Limit: b.limit}
+			if ms, ok := b.r.(*multiSource); ok {
+				budgetErr.Source = sourceName(ms.readers[ms.last])
+			}
+			return 0, budgetErr
+		}
+		if err == io.ErrUnexpectedEOF {
+			err = io.EOF
+		}
+		return 0, err
+	}
+	if int64(len(p)) > b.remaining {
+		p = p[:b.remaining]
+	}
+	n, err := b.r.Read(p)
+	b.remaining -= int64(n)
+	return n, err
+}
+
+// accept reports whether token, described by info, passes the filter of r.
+func (r *Reader) accept(token string, info TokenInfo) bool {
+	switch {
+	case r.filter != nil:
+		return r.filter(token)
+	case r.filterInfo != nil:
+		return r.filterInfo(info)
+	}
+	return true
 }
@@ -2,9 +2,15 @@ package textio
 
 import (
 	"bytes"
+	"fmt"
 	"io"
+	"io/fs"
+	"math"
 	"os"
+	"path/filepath"
 	"strings"
+
+	"github.com/JFinlayM/textio/internal/core"
 )
 
 // TokenReaderCloser extends TokenReader with explicit resource management.
@@ -39,7 +45,7 @@ type TokenReaderStreamerCloser interface {
 // normalization and filtering before returning them.
 //
 // [ReaderCloser] supports both batch and streaming consumption patterns.
-// The tokens read with [ReaderCloser] are either seperate with a string delimiter [delimiterStr] or a regular expression [delimiter].
+// The tokens read with [ReaderCloser] are seperated according to its [Delimiter], either with a string or a regular expression.
 // The readers that are closeable are stored and so can be close via [ReaderCloser] with Close function.
 type ReaderCloser struct {
 	*Reader
@@ -86,17 +92,126 @@ func (rc *ReaderCloser) FromBytes(b []byte) *ReaderCloser {
 // [FromFile] returns a shallow copy of the [ReaderCloser]
 // with a new reader from the file. This discards and closes the previously set readers.
 //
+// Files compressed with gzip, bzip2 or zstd, such as rotated logs, are decompressed
+// transparently, see [WrapCompression]; errors identifying the format are
+// reported with [ErrOpen].
+//
 // The original [ReaderCloser] is not modified.
 func (rc *ReaderCloser) FromFile(path string) (*ReaderCloser, error) {
 	file, err := os.Open(path)
 	if err != nil {
 		return nil, newErrOpen(err)
 	}
+	src, err := WrapCompression(file)
+	if err != nil {
+		file.Close()
+		return nil, newErrOpen(err)
+	}
 	newR := *rc
-	newR.SetReaders(file)
+	newR.SetReaders(src)
 	return &newR, nil
 }
 
+// [FromGlob] returns a shallow copy of the [ReaderCloser] with a new reader from each
+// file matching pattern, read one after the other in lexical order (see [filepath.Glob]).
+// This discards and closes the previously set readers. A pattern matching no file
+// gives an empty input, and a malformed pattern is reported with [ErrOpen].
+//
+// Each file is opened when it starts being read and closed once read to the end,
+// so that folders of any size can be read; [ReaderCloser.Close] closes the file
+// being read, if any. A file that cannot be opened is reported like other read
+// errors (see [ErrRead]). Tokens are reported with the path of their file (see [TokenInfo]).
+// Compressed files are decompressed as with [ReaderCloser.FromFile].
+//
+// The original [ReaderCloser] is not modified.
+func (rc *ReaderCloser) FromGlob(pattern string) (*ReaderCloser, error) {
+	paths, err := filepath.Glob(pattern)
+	if err != nil {
+		return nil, newErrOpen(err)
+	}
+	return rc.fromPaths(paths), nil
+}
+
+// [FromDir] returns a shallow copy of the [ReaderCloser] with a new reader from each
+// regular file of the directory dir, and of its subdirectories if recursive is set,
+// read one after the other in lexical order (see [filepath.WalkDir]).
+// This discards and closes the previously set readers. A directory that cannot
+// be read is reported with [ErrOpen]. Files are read as with [ReaderCloser.FromGlob].
+//
+// The original [ReaderCloser] is not modified.
+func (rc *ReaderCloser) FromDir(dir string, recursive bool) (*ReaderCloser, error) {
+	var paths []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && path != dir && !recursive {
+			return filepath.SkipDir
+		}
+		if d.Type().IsRegular() {
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, newErrOpen(err)
+	}
+	return rc.fromPaths(paths), nil
+}
+
+// fromPaths returns a shallow copy of rc reading the files at paths in order.
+func (rc *ReaderCloser) fromPaths(paths []string) *ReaderCloser {
+	readers := make([]io.Reader, len(paths))
+	for i, path := range paths {
+		readers[i] = &lazyFile{path: path}
+	}
+	newR := *rc
+	newR.SetReaders(readers...)
+	return &newR
+}
+
+// [FromFileRange] returns a copy of the [ReaderCloser] reading the tokens of the file
+// at path that start within the byte range [offset, offset+length).
+//
+// This allows shards of a huge file to be tokenized independently by parallel workers:
+// a token crossing the start of the range belongs to the previous shard and is skipped,
+// while a token crossing the end of the range is read up to its end. Splitting a file in
+// consecutive ranges therefore yields every token exactly once.
+// The delimiter must be set before calling FromFileRange. Regular expression delimiters
+// are detected with a look-behind of their longest match, and rune class delimiters
+// (see [Delimiter.SetTokenFunc]) with a one rune look-behind. Regular expressions whose
+// matches have no maximum length, such as `\s+`, cannot be split at arbitrary offsets
+// and are reported with [ErrOpen].
+//
+// Unlike [ReaderCloser.FromFile], the original [ReaderCloser] is neither modified nor closed,
+// so that several ranges can be read concurrently from the same template.
+// The [TokenInfo] offsets of the tokens are relative to the start of the range.
+func (rc *ReaderCloser) FromFileRange(path string, offset, length int64) (*ReaderCloser, error) {
+	if offset < 0 || length < 0 {
+		return nil, newErrOpen(fmt.Errorf("invalid range [%d, %d+%d)", offset, offset, length))
+	}
+	lookback := core.Lookback(rc.delimiter)
+	if lookback < 0 {
+		return nil, newErrOpen(fmt.Errorf("delimiter %s has unbounded matches", core.TokenPattern(rc.delimiter)))
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, newErrOpen(err)
+	}
+
+	lookback = min(offset, lookback)
+	section := io.NewSectionReader(file, offset-lookback, math.MaxInt64-(offset-lookback))
+
+	newR := &ReaderCloser{Reader: rc.Reader.clone()}
+	newR.Reader.setReaders(Named(path, section))
+	newR.closers = append(newR.closers, file)
+	newR.byteRange = &byteRange{
+		begin: lookback,
+		end:   lookback + length,
+	}
+	return newR, nil
+}
+
 // WithDelimiter returns a shallow copy of the [ReaderCloser]
 // configured with the given delimiter regular expression.
 //
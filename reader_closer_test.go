@@ -1,7 +1,22 @@
 package textio
 
 import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
 )
 
 func TestClose(t *testing.T) {
@@ -30,3 +45,259 @@ func TestClose(t *testing.T) {
 		}
 	}
 }
+
+func TestFromFileRange(t *testing.T) {
+	content := "alpha\nbeta\ngamma\ndelta\nepsilon\nzeta\neta\ntheta"
+	path := filepath.Join(t.TempDir(), "shards.txt")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := strings.Split(content, "\n")
+	for _, size := range []int64{1, 3, 5, 6, 11, 100} {
+		var tokens []string
+		for off := int64(0); off < int64(len(content)); off += size {
+			rc, err := NewReaderCloser().FromFileRange(path, off, size)
+			if err != nil {
+				t.Fatalf("FromFileRange() error = %v", err)
+			}
+			shard, err := rc.ReadTokens()
+			rc.Close()
+			if err != nil {
+				t.Fatalf("ReadTokens() error = %v", err)
+			}
+			tokens = append(tokens, shard...)
+		}
+
+		if strings.Join(tokens, ",") != strings.Join(expected, ",") {
+			t.Errorf("size %d: got tokens %v, want %v", size, tokens, expected)
+		}
+	}
+}
+
+func TestFromFileRange_Regexp(t *testing.T) {
+	for _, tc := range []struct {
+		content, expr string
+	}{
+		{"aaa\r\nbbb\r\nccc\r\nddd", `\r\n`},
+		{"aaa\r\nbbb\nccc\r\nddd\n", `\r?\n`},
+		{"one--two---three--four", `---?`},
+	} {
+		path := filepath.Join(t.TempDir(), "shards.txt")
+		if err := os.WriteFile(path, []byte(tc.content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		rc := NewReaderCloser()
+		rc.SetDelimiter(NewDelimiter().WithTokenRegexpFromString(tc.expr))
+		expected, err := rc.FromString(tc.content).ReadTokens()
+		if err != nil {
+			t.Fatalf("ReadTokens() error = %v", err)
+		}
+
+		size := int64(len(tc.content))
+		for split := int64(0); split <= size; split++ {
+			var tokens []string
+			for _, rg := range [][2]int64{{0, split}, {split, size - split}} {
+				shard, err := rc.FromFileRange(path, rg[0], rg[1])
+				if err != nil {
+					t.Fatalf("FromFileRange() error = %v", err)
+				}
+				part, err := shard.ReadTokens()
+				shard.Close()
+				if err != nil {
+					t.Fatalf("ReadTokens() error = %v", err)
+				}
+				tokens = append(tokens, part...)
+			}
+			if strings.Join(tokens, "|") != strings.Join(expected, "|") {
+				t.Errorf("%q split at %d: got %q, want %q", tc.expr, split, tokens, expected)
+			}
+		}
+	}
+
+	rc := NewReaderCloser()
+	rc.SetDelimiter(NewDelimiter().WithTokenRegexpFromString(`\s+`))
+	if _, err := rc.FromFileRange("reader_closer_test.txt", 0, 10); !errors.Is(err, ErrOpen) {
+		t.Errorf("FromFileRange() error = %v, want %v for an unbounded delimiter", err, ErrOpen)
+	}
+}
+
+func TestFromGlobFromDir(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"b.log":       "b1\nb2\n",
+		"a.log":       "a1\n",
+		"notes.txt":   "n1\n",
+		"sub/c.log":   "c1\n",
+		"sub/d/e.log": "e1\n",
+	} {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, tc := range []struct {
+		name string
+		open func() (*ReaderCloser, error)
+		want string
+	}{
+		{"glob", func() (*ReaderCloser, error) { return NewReaderCloser().FromGlob(filepath.Join(dir, "*.log")) }, "a1|b1|b2"},
+		{"dir", func() (*ReaderCloser, error) { return NewReaderCloser().FromDir(dir, false) }, "a1|b1|b2|n1"},
+		{"recursive", func() (*ReaderCloser, error) { return NewReaderCloser().FromDir(dir, true) }, "a1|b1|b2|n1|c1|e1"},
+	} {
+		rc, err := tc.open()
+		if err != nil {
+			t.Fatalf("%s: error = %v", tc.name, err)
+		}
+		tokens, err := rc.ReadTokens()
+		if err != nil {
+			t.Fatalf("%s: ReadTokens() error = %v", tc.name, err)
+		}
+		if got := strings.Join(tokens, "|"); got != tc.want {
+			t.Errorf("%s: got %q, want %q", tc.name, got, tc.want)
+		}
+		if err := rc.Close(); err != nil {
+			t.Errorf("%s: Close() error = %v", tc.name, err)
+		}
+	}
+
+	rc, err := NewReaderCloser().FromDir(dir, true)
+	if err != nil {
+		t.Fatalf("FromDir() error = %v", err)
+	}
+	if tokens, err := rc.ReadTokens(WithLimit(1)); err != nil || len(tokens) != 1 {
+		t.Fatalf("ReadTokens(WithLimit(1)) = %q, %v", tokens, err)
+	}
+	if err := rc.Close(); err != nil {
+		t.Errorf("Close() of a partially read file error = %v", err)
+	}
+
+	if _, err := NewReaderCloser().FromGlob("["); !errors.Is(err, ErrOpen) {
+		t.Errorf("FromGlob() error = %v, want %v", err, ErrOpen)
+	}
+	if _, err := NewReaderCloser().FromDir(filepath.Join(dir, "missing"), false); !errors.Is(err, ErrOpen) {
+		t.Errorf("FromDir() error = %v, want %v", err, ErrOpen)
+	}
+}
+
+func TestFromFile_Decompress(t *testing.T) {
+	var gz bytes.Buffer
+	zw := gzip.NewWriter(&gz)
+	zw.Write([]byte("a\nb\n"))
+	zw.Close()
+	var zst bytes.Buffer
+	zsw, err := zstd.NewWriter(&zst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zsw.Write([]byte("z\nw\n"))
+	zsw.Close()
+	// printf 'x\ny\n' | bzip2
+	bz := []byte("BZh91AY&SY\x06\xe4\xe9\x9e\x00\x00\x01\x40\x80\x00\x10\x00\x60\x20\x00\x30\xcc\x0c\x7a\x82\x71\x77\x24\x53\x85\x09\x00\x6e\x4e\x99\xe0")
+
+	dir := t.TempDir()
+	for name, tc := range map[string]struct {
+		data []byte
+		want string
+	}{
+		"plain.log":   {[]byte("p\nq\n"), "p|q"},
+		"app.log.gz":  {gz.Bytes(), "a|b"},
+		"app.log.bz2": {bz, "x|y"},
+		"app.log.zst": {zst.Bytes(), "z|w"},
+		"empty.log":   {nil, ""},
+	} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, tc.data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		rc, err := NewReaderCloser().FromFile(path)
+		if err != nil {
+			t.Fatalf("FromFile(%s) error = %v", name, err)
+		}
+		tokens, err := rc.ReadTokens()
+		rc.Close()
+		if err != nil {
+			t.Fatalf("%s: ReadTokens() error = %v", name, err)
+		}
+		if got := strings.Join(tokens, "|"); got != tc.want {
+			t.Errorf("%s: got %q, want %q", name, got, tc.want)
+		}
+	}
+
+	// Files read lazily are decompressed too.
+	rc, err := NewReaderCloser().FromGlob(filepath.Join(dir, "*.log*"))
+	if err != nil {
+		t.Fatalf("FromGlob() error = %v", err)
+	}
+	tokens, err := rc.ReadTokens()
+	rc.Close()
+	if got := strings.Join(tokens, "|"); err != nil || got != "x|y|a|b|z|w|p|q" {
+		t.Errorf("FromGlob().ReadTokens() = %q, %v, want %q", got, err, "x|y|a|b|z|w|p|q")
+	}
+	tokens, err = NewReader().FromFile(filepath.Join(dir, "app.log.zst")).ReadTokens()
+	if got := strings.Join(tokens, "|"); err != nil || got != "z|w" {
+		t.Errorf("Reader.FromFile().ReadTokens() = %q, %v, want %q", got, err, "z|w")
+	}
+
+	r := NewReader()
+	if err := r.SetCodec("auto"); err != nil {
+		t.Fatalf("SetCodec(\"auto\") error = %v", err)
+	}
+	tokens, err = r.WithReaders(bytes.NewReader(gz.Bytes()), iotest.OneByteReader(strings.NewReader("c\n"))).ReadTokens()
+	if err != nil {
+		t.Fatalf("ReadTokens() error = %v", err)
+	}
+	if got := strings.Join(tokens, "|"); got != "a|b|c" {
+		t.Errorf("got %q, want %q", got, "a|b|c")
+	}
+}
+
+func TestFromURL(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch {
+		case req.URL.Path == "/missing":
+			http.NotFound(w, req)
+		case calls.Add(1) < 3:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			io.WriteString(w, "alpha\nbeta\n")
+		}
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	if _, err := NewReaderCloser().FromURL(ctx, srv.URL+"/words", WithRetries(1, time.Millisecond)); !errors.Is(err, ErrOpen) {
+		t.Fatalf("FromURL() error = %v, want %v after too few retries", err, ErrOpen)
+	}
+
+	calls.Store(0)
+	rc, err := NewReaderCloser().FromURL(ctx, srv.URL+"/words", WithRetries(2, time.Millisecond), WithHTTPClient(srv.Client()))
+	if err != nil {
+		t.Fatalf("FromURL() error = %v", err)
+	}
+	defer rc.Close()
+	var sources []string
+	rc.SetFilterInfo(func(info TokenInfo) bool {
+		sources = append(sources, info.Source)
+		return true
+	})
+	tokens, err := rc.ReadTokens()
+	if err != nil {
+		t.Fatalf("ReadTokens() error = %v", err)
+	}
+	if strings.Join(tokens, "|") != "alpha|beta" || sources[0] != srv.URL+"/words" {
+		t.Errorf("got %q from %q, want [alpha beta] from %s/words", tokens, sources, srv.URL)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("got %d requests, want 3", calls.Load())
+	}
+
+	if _, err := NewReaderCloser().FromURL(ctx, srv.URL+"/missing", WithRetries(3, time.Millisecond)); !errors.Is(err, ErrOpen) || !strings.Contains(err.Error(), "404") {
+		t.Errorf("FromURL() error = %v, want %v with status 404", err, ErrOpen)
+	}
+}
@@ -0,0 +1,59 @@
+package textio
+
+// CtxNormalizeFunc is the typed counterpart of [NormalizeFunc] used by [ReaderCtx].
+// ctx is the user context of the [ReaderCtx] and s the token currently being read.
+type CtxNormalizeFunc[C any] func(ctx C, s string) string
+
+// CtxFilterFunc is the typed counterpart of [FilterFunc] used by [ReaderCtx].
+// ctx is the user context of the [ReaderCtx] and s the token currently being read.
+// Should return true is the token satisfies user defined constraints, false otherwise.
+type CtxFilterFunc[C any] func(ctx C, s string) bool
+
+// [ReaderCtx] is a [Reader] carrying a typed user context.
+//
+// The context is passed to the normalization and filtering functions set with
+// [ReaderCtx.SetNormalizer] and [ReaderCtx.SetFilter], so per-pipeline state
+// (counters, dictionaries...) is available without type assertions.
+// Use a pointer type for C when the functions need to update the context.
+type ReaderCtx[C any] struct {
+	*Reader
+	// Ctx is the user context given to the normalization and filtering functions.
+	Ctx C
+}
+
+// NewReaderCtx creates a [ReaderCtx] wrapping r with the user context ctx.
+//
+// If r is nil, a [Reader] with default configuration is used.
+func NewReaderCtx[C any](r *Reader, ctx C) *ReaderCtx[C] {
+	if r == nil {
+		r = NewReader()
+	}
+	return &ReaderCtx[C]{
+		Reader: r,
+		Ctx:    ctx,
+	}
+}
+
+// Sets the function to be called with the user context to normalize current read token before passing through filter function.
+// A nil n removes the normalization function.
+func (rc *ReaderCtx[C]) SetNormalizer(n CtxNormalizeFunc[C]) {
+	if n == nil {
+		rc.Reader.SetNormalizer(nil)
+		return
+	}
+	rc.Reader.SetNormalizer(func(s string) string {
+		return n(rc.Ctx, s)
+	})
+}
+
+// Sets the function to be called with the user context to filter current read token.
+// A nil f removes the filter function.
+func (rc *ReaderCtx[C]) SetFilter(f CtxFilterFunc[C]) {
+	if f == nil {
+		rc.Reader.SetFilter(nil)
+		return
+	}
+	rc.Reader.SetFilter(func(s string) bool {
+		return f(rc.Ctx, s)
+	})
+}
@@ -0,0 +1,255 @@
+package textio
+
+import (
+	"bufio"
+	"encoding"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/JFinlayM/textio/internal/core"
+	"github.com/JFinlayM/textio/normalizers"
+)
+
+var durationType = reflect.TypeFor[time.Duration]()
+
+// SetFieldDelimiter sets the delimiter splitting the records read by
+// [Reader.ReadRecordsInto] into fields. By default, records are comma-separated
+// values with optional double quotes (see [CSVPreset]).
+func (r *Reader) SetFieldDelimiter(d *Delimiter) {
+	r.fields = d
+}
+
+// ReadRecordsInto reads the tokens of r as records, splits them into fields with the
+// field delimiter (see [Reader.SetFieldDelimiter]), and appends them to dst, which must
+// be a pointer to a slice of structs or of pointers to structs.
+//
+// The struct fields receiving the record fields are selected with tags, either by
+// column index or by column name:
+//
+//	type Person struct {
+//		Name string `textio:"0"`
+//		Age  int    `textio:"1"`
+//	}
+//
+//	type Person struct {
+//		Name string `textio:"name"`
+//		Age  int    `textio:"age"`
+//	}
+//
+// With column names, the first record is the header naming the columns, in any order.
+// Untagged fields and fields tagged "-" are left unset. Fields are trimmed of white
+// space and unquoted. Struct fields may be strings, booleans, integers, floating point
+// numbers, [time.Duration] values, or implement [encoding.TextUnmarshaler].
+//
+// A record that fails to decode stops the read with [ErrInvalid]; the returned
+// [ReaderError] holds the record, its index among the tokens read, and the error,
+// which names the column. Records decoded before a failure are kept in dst.
+func (r *Reader) ReadRecordsInto(dst any, opts ...ReadOption) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Slice {
+		return errors.New("textio: ReadRecordsInto destination must be a non-nil pointer to a slice")
+	}
+	slice := v.Elem()
+	elem := slice.Type().Elem()
+	isPtr := elem.Kind() == reflect.Pointer
+	if isPtr {
+		elem = elem.Elem()
+	}
+	if elem.Kind() != reflect.Struct {
+		return errors.New("textio: ReadRecordsInto elements must be structs or pointers to structs")
+	}
+	columns, named, err := recordColumns(elem)
+	if err != nil {
+		return err
+	}
+
+	r = r.apply(opts)
+	fields := r.fields
+	if fields == nil {
+		fields = CSVPreset()
+	}
+	it := r.iter()
+	defer it.close()
+	for index := 0; ; index++ {
+		token, err := it.next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		values, err := splitRecord(fields, token)
+		if err != nil {
+			return it.decodeError(token, index, err)
+		}
+
+		if named {
+			if err := resolveColumns(columns, values); err != nil {
+				return it.decodeError(token, index, err)
+			}
+			named = false
+			continue
+		}
+
+		val := reflect.New(elem)
+		for _, c := range columns {
+			if c.index >= len(values) {
+				return it.decodeError(token, index, fmt.Errorf("column %s: missing", c))
+			}
+			if err := setField(val.Elem().FieldByIndex(c.field), values[c.index]); err != nil {
+				return it.decodeError(token, index, fmt.Errorf("column %s: %w", c, err))
+			}
+		}
+		if !isPtr {
+			val = val.Elem()
+		}
+		slice.Set(reflect.Append(slice, val))
+	}
+}
+
+// recordColumn is a struct field receiving the column of a record.
+type recordColumn struct {
+	// field is the index of the struct field, see [reflect.Value.FieldByIndex].
+	field []int
+	// name is the name of the column, empty if it is selected by index.
+	name  string
+	index int
+}
+
+func (c recordColumn) String() string {
+	if c.name != "" {
+		return strconv.Quote(c.name)
+	}
+	return strconv.Itoa(c.index)
+}
+
+// recordColumns returns the columns of the tagged fields of t, and whether they are
+// selected by name.
+func recordColumns(t reflect.Type) ([]recordColumn, bool, error) {
+	var columns []recordColumn
+	named, indexed := false, false
+	for _, f := range reflect.VisibleFields(t) {
+		tag, ok := f.Tag.Lookup("textio")
+		if !ok || tag == "-" || !f.IsExported() {
+			continue
+		}
+		if !settable(f.Type) {
+			return nil, false, fmt.Errorf("textio: ReadRecordsInto cannot decode field %s of type %s", f.Name, f.Type)
+		}
+		c := recordColumn{field: f.Index, name: tag}
+		if n, err := strconv.Atoi(tag); err == nil && n >= 0 {
+			c.name, c.index = "", n
+			indexed = true
+		} else {
+			named = true
+		}
+		columns = append(columns, c)
+	}
+	if named && indexed {
+		return nil, false, errors.New("textio: ReadRecordsInto fields must be tagged either all by index or all by name")
+	}
+	return columns, named, nil
+}
+
+// resolveColumns sets the index of the named columns from the header of a record.
+func resolveColumns(columns []recordColumn, header []string) error {
+	for i := range columns {
+		j := -1
+		for k, name := range header {
+			if name == columns[i].name {
+				j = k
+				break
+			}
+		}
+		if j < 0 {
+			return fmt.Errorf("column %s: not in header", columns[i])
+		}
+		columns[i].index = j
+	}
+	return nil
+}
+
+// splitRecord splits record into trimmed and unquoted fields with d.
+func splitRecord(d *Delimiter, record string) ([]string, error) {
+	normalize := NormalizeTrimSpace
+	if quote := core.Quote(d); len(quote) > 0 {
+		q, _ := utf8.DecodeRune(quote)
+		normalize = ChainNormalizers(NormalizeTrimSpace, normalizers.Unquote(q))
+	}
+	scanner := bufio.NewScanner(strings.NewReader(record))
+	scanner.Buffer(make([]byte, 0, len(record)+1), len(record)+1)
+	// A record ending with a separator has an empty last field.
+	fd := *d
+	fd.SetTrailingEmpty(true)
+	scanner.Split(fd.SplitFunc())
+	var fields []string
+	for scanner.Scan() {
+		fields = append(fields, normalize(scanner.Text()))
+	}
+	return fields, scanner.Err()
+}
+
+// settable reports whether setField can decode a value of type t.
+func settable(t reflect.Type) bool {
+	if reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// setField decodes s into v, whose type is settable.
+func setField(v reflect.Value, s string) error {
+	if u, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(s))
+	}
+	if v.Type() == durationType {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return err
+		}
+		v.SetInt(int64(d))
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(s, v.Type().Bits())
+		if err != nil {
+			return err
+		}
+		v.SetFloat(f)
+	}
+	return nil
+}
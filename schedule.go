@@ -0,0 +1,274 @@
+package textio
+
+import (
+	"io"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// scheduleMode is the way a [Schedule] picks the source of the next token.
+type scheduleMode int
+
+const (
+	scheduleSequential scheduleMode = iota
+	scheduleTokens
+	scheduleBytes
+	schedulePriority
+)
+
+// Schedule is the order in which a [Reader] reads tokens from its sources,
+// see [Reader.SetSchedule].
+type Schedule struct {
+	mode scheduleMode
+	// quantum is the number of tokens or bytes read from a source per turn.
+	quantum int
+	// priorities holds the priority level of each source.
+	priorities []int
+	// idle is the time to wait for the preferred source before
+	// taking a token from another one, see [Schedule.WithIdleTimeout].
+	idle time.Duration
+}
+
+// ScheduleSequential reads the sources one after the other, in the manner of
+// [io.MultiReader]. This is the default schedule.
+func ScheduleSequential() Schedule {
+	return Schedule{}
+}
+
+// ScheduleRoundRobin reads up to n tokens from each source in turn.
+func ScheduleRoundRobin(n int) Schedule {
+	return Schedule{mode: scheduleTokens, quantum: max(n, 1)}
+}
+
+// ScheduleByteChunks reads tokens from each source in turn, moving to the next
+// source once the tokens read from the current one total size bytes or more.
+func ScheduleByteChunks(size int) Schedule {
+	return Schedule{mode: scheduleBytes, quantum: max(size, 1)}
+}
+
+// SchedulePriority reads the tokens of the sources with the highest priority level
+// first: levels[i] is the level of the i-th source, 0 for sources without a level.
+// Sources of the same level are read in turn.
+func SchedulePriority(levels ...int) Schedule {
+	return Schedule{mode: schedulePriority, quantum: 1, priorities: append([]int(nil), levels...)}
+}
+
+// WithIdleTimeout returns a copy of s waiting at most d for a token of the preferred
+// source. Past that time, the next token is taken from whichever source has one
+// ready first, so a live source waiting for input, such as a tailed file or a socket, does
+// not hold back the others. A d of 0 or less waits for the preferred source until it
+// is exhausted, which is the default.
+func (s Schedule) WithIdleTimeout(d time.Duration) Schedule {
+	s.idle = d
+	return s
+}
+
+// SetSchedule sets the order in which the tokens of the sources are read.
+//
+// With any schedule but [ScheduleSequential], each source is split on its own and
+// read ahead concurrently, and the next token is taken from the preferred source of
+// the schedule, waiting for it as needed: the turn only moves to another source once
+// the preferred one has used its quantum or is exhausted, so the interleaving of the
+// tokens does not depend on the timing of the sources. For interleaved tailing of live
+// sources, see [Schedule.WithIdleTimeout]. The tokens of a given source keep their
+// order, and the byte budget of [Reader.SetMaxBytes] applies to each source separately.
+func (r *Reader) SetSchedule(s Schedule) {
+	r.schedule = s
+}
+
+// scheduled is an item read ahead from a source of a [scheduler].
+type scheduled struct {
+	text   string
+	kind   TokenKind
+	source string
+	offset int64
+	err    error
+}
+
+// scheduler is the [TokenSource] of a [Reader] reading its sources
+// according to a [Schedule].
+type scheduler struct {
+	sched Schedule
+	// chans receive the tokens of each source, nil once it is exhausted.
+	chans []chan scheduled
+	done  chan struct{}
+	// cur is the source of the current turn, and used the tokens or
+	// bytes it consumed in that turn.
+	cur, used int
+	item      scheduled
+	err       error
+	// hold keeps the turn on the current source, to join its continued
+	// lines, and again makes Scan return the current item once more.
+	hold, again bool
+}
+
+// newScheduler starts reading ahead every source of r.
+func newScheduler(r *Reader) *scheduler {
+	s := &scheduler{
+		sched: r.schedule,
+		chans: make([]chan scheduled, len(r.sources)),
+		done:  make(chan struct{}),
+		cur:   -1,
+	}
+	ms := r.input()
+	for i, src := range r.sources {
+		sub := r.clone()
+		sub.setReaders(src)
+		sub.schedule = Schedule{}
+		// The tokens are processed once, by the iterator of r.
+		sub.normalize, sub.normalizeInfo = nil, nil
+		sub.filter, sub.filterInfo = nil, nil
+		sub.mapper, sub.emptyDefault = nil, nil
+		sub.continuation, sub.detectLang = "", nil
+		sub.limit, sub.maxTokens, sub.byteRange = 0, 0, nil
+		sub.FailOnInvalid = false
+		if ms != nil {
+			// Account the bytes of the source to r.
+			sub.input().counts = ms.counts[i : i+1 : i+1]
+		}
+		s.chans[i] = make(chan scheduled, 1)
+		go s.readAhead(sub.iter(), s.chans[i])
+	}
+	return s
+}
+
+// readAhead sends the raw tokens of it to ch until it is exhausted or s is stopped.
+func (s *scheduler) readAhead(it *tokenIter, ch chan scheduled) {
+	defer close(ch)
+	for {
+		text, err := it.next()
+		if err == io.EOF {
+			return
+		}
+		item := scheduled{text: text, kind: it.kind, err: err}
+		item.source, item.offset = it.position()
+		select {
+		case ch <- item:
+		case <-s.done:
+			return
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// stop releases the goroutines reading ahead.
+func (s *scheduler) stop() {
+	select {
+	case <-s.done:
+	default:
+		close(s.done)
+	}
+}
+
+func (s *scheduler) Scan() bool {
+	if s.err != nil {
+		return false
+	}
+	if s.again {
+		s.again = false
+		return true
+	}
+	for {
+		order := s.order()
+		if len(order) == 0 {
+			return false
+		}
+		i, item, ok := s.receive(order)
+		if !ok {
+			s.chans[i] = nil
+			continue
+		}
+		if item.err != nil {
+			s.err = item.err
+			s.stop()
+			return false
+		}
+		if i != s.cur {
+			s.cur, s.used = i, 0
+		}
+		if s.sched.mode == scheduleBytes {
+			s.used += len(item.text)
+		} else if item.kind == KindContent {
+			s.used++
+		}
+		s.item = item
+		return true
+	}
+}
+
+// receive waits for the next item of the preferred source, the first of order.
+// Past the idle timeout, if any, it takes the item of whichever source of order
+// has one ready first.
+// ok is false if the source i is exhausted.
+func (s *scheduler) receive(order []int) (i int, item scheduled, ok bool) {
+	i = order[0]
+	if s.sched.idle <= 0 {
+		item, ok = <-s.chans[i]
+		return i, item, ok
+	}
+	timer := time.NewTimer(s.sched.idle)
+	defer timer.Stop()
+	select {
+	case item, ok = <-s.chans[i]:
+		return i, item, ok
+	case <-timer.C:
+	}
+
+	cases := make([]reflect.SelectCase, len(order))
+	for k, j := range order {
+		cases[k] = reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(s.chans[j])}
+	}
+	k, v, ok := reflect.Select(cases)
+	if ok {
+		item = v.Interface().(scheduled)
+	}
+	return order[k], item, ok
+}
+
+// unscan makes the next call to Scan return the current item again.
+func (s *scheduler) unscan() {
+	s.again = true
+}
+
+// order returns the sources that are not exhausted, the preferred one first.
+func (s *scheduler) order() []int {
+	if s.hold && s.cur >= 0 && s.chans[s.cur] != nil {
+		return []int{s.cur}
+	}
+	n := len(s.chans)
+	// The current source keeps its turn until its quantum is used.
+	start := s.cur + 1
+	if s.cur >= 0 && s.used < s.sched.quantum {
+		start = s.cur
+	}
+	var order []int
+	for k := range n {
+		if i := (start + k) % n; s.chans[i] != nil {
+			order = append(order, i)
+		}
+	}
+	if s.sched.mode == schedulePriority {
+		sort.SliceStable(order, func(a, b int) bool {
+			return s.priority(order[a]) > s.priority(order[b])
+		})
+	}
+	return order
+}
+
+func (s *scheduler) priority(i int) int {
+	if i < len(s.sched.priorities) {
+		return s.sched.priorities[i]
+	}
+	return 0
+}
+
+func (s *scheduler) Text() string {
+	return s.item.text
+}
+
+func (s *scheduler) Err() error {
+	return s.err
+}
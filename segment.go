@@ -0,0 +1,59 @@
+package textio
+
+import (
+	"io"
+	"strings"
+
+	"github.com/JFinlayM/textio/internal/core"
+)
+
+// Segment is a piece of input read by [Reader.ReadSegments].
+type Segment struct {
+	// Text is the normalized text of a content segment, or the delimiter itself.
+	Text string
+	// Raw is the segment as found in the input.
+	Raw  string
+	Kind TokenKind
+}
+
+// ReadSegments reads the input as an alternating sequence of content and delimiter
+// segments, whatever the [Delimiter.SetEmitDelimiters] setting of the delimiter.
+//
+// Content segments go through normalization and filtering as with [Reader.ReadTokens],
+// delimiter segments are always kept. As long as no content segment is rejected,
+// passing the result to [Rebuild] gives back the input byte for byte, except for
+// what follows a matched stop pattern.
+func (r *Reader) ReadSegments(opts ...ReadOption) ([]Segment, error) {
+	r = r.apply(opts)
+	if !core.EmitsDelimiters(r.delimiter) {
+		d := *r.delimiter
+		d.SetEmitDelimiters(true)
+		newR := *r
+		newR.delimiter = &d
+		r = &newR
+	}
+
+	var segments []Segment
+	it := r.iter()
+	defer it.close()
+	for {
+		text, err := it.next()
+		if err == io.EOF {
+			return segments, nil
+		}
+		if err != nil {
+			return segments, err
+		}
+		segments = append(segments, Segment{Text: text, Raw: it.raw, Kind: it.kind})
+	}
+}
+
+// Rebuild concatenates the raw text of segments, reconstructing the input
+// they were read from by [Reader.ReadSegments].
+func Rebuild(segments []Segment) string {
+	var sb strings.Builder
+	for _, s := range segments {
+		sb.WriteString(s.Raw)
+	}
+	return sb.String()
+}
@@ -0,0 +1,261 @@
+package textio
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync/atomic"
+
+	"golang.org/x/text/encoding"
+)
+
+// Named returns an [io.Reader] reading from r whose tokens are reported
+// with the source name name (see [TokenInfo]).
+func Named(name string, r io.Reader) io.Reader {
+	return &namedReader{Reader: r, name: name}
+}
+
+type namedReader struct {
+	io.Reader
+	name string
+}
+
+func (n *namedReader) Name() string {
+	return n.name
+}
+
+// lazyFile reads the file at path, opening it on the first read and closing it
+// once read to the end, see [Reader.FromFile] and [ReaderCloser.FromGlob].
+// Compressed files are decompressed, see [WrapCompression].
+type lazyFile struct {
+	path string
+	f    *os.File
+	// src reads the data of f, decompressed if needed.
+	src  io.Reader
+	done bool
+}
+
+func (l *lazyFile) Read(p []byte) (int, error) {
+	if l.done {
+		return 0, io.EOF
+	}
+	if l.f == nil {
+		f, err := os.Open(l.path)
+		if err != nil {
+			l.done = true
+			return 0, err
+		}
+		l.f = f
+		src, err := WrapCompression(f)
+		if err != nil {
+			l.done = true
+			f.Close()
+			return 0, err
+		}
+		l.src = src
+	}
+	n, err := l.src.Read(p)
+	if err != nil {
+		l.done = true
+		if closeErr := l.close(); err == io.EOF && closeErr != nil {
+			err = closeErr
+		}
+	}
+	return n, err
+}
+
+// Close closes the file if it is open.
+func (l *lazyFile) Close() error {
+	if l.f == nil || l.done {
+		l.done = true
+		return nil
+	}
+	l.done = true
+	return l.close()
+}
+
+// close closes src, which closes f.
+func (l *lazyFile) close() error {
+	return l.src.(io.Closer).Close()
+}
+
+func (l *lazyFile) Name() string {
+	return l.path
+}
+
+// sourceName returns the name of rd if it has a Name method
+// (such as [os.File]), or an empty string otherwise.
+func sourceName(rd io.Reader) string {
+	if n, ok := rd.(interface{ Name() string }); ok {
+		return n.Name()
+	}
+	return ""
+}
+
+// seeker returns rd, or the reader wrapped by [Named], as an [io.Seeker].
+func seeker(rd io.Reader) (io.Seeker, bool) {
+	if n, ok := rd.(*namedReader); ok {
+		rd = n.Reader
+	}
+	s, ok := rd.(io.Seeker)
+	return s, ok
+}
+
+// multiSource reads sequentially from several readers like [io.MultiReader],
+// and records the offset at which each of them starts in the combined stream.
+type multiSource struct {
+	readers []io.Reader
+	current int
+	// read is the number of bytes read so far.
+	read int64
+	// starts holds the offset in the combined stream of readers[:current+1].
+	starts []int64
+	// binary is the policy applied to sources that look binary, and
+	// pending the bytes of the current source read while sniffing it.
+	binary  BinaryPolicy
+	pending []byte
+	// codec decodes every source when set, and src is
+	// the current source, decoded.
+	codec Codec
+	src   io.Reader
+	// charset transcodes every source to UTF-8 when set, after decoding.
+	charset encoding.Encoding
+	// last is the index of the reader that last returned bytes.
+	last int
+	// counts holds the number of bytes read from each reader,
+	// and stats the token counters of the input, see [Reader.Stats].
+	counts []atomic.Int64
+	stats  tokenStats
+}
+
+func newMultiSource(readers []io.Reader) *multiSource {
+	return &multiSource{readers: readers, counts: make([]atomic.Int64, len(readers))}
+}
+
+// input returns the combined input stream of r, or nil if it has none.
+func (r *Reader) input() *multiSource {
+	ms, _ := r.reader.(*multiSource)
+	return ms
+}
+
+func (m *multiSource) Read(p []byte) (int, error) {
+	for m.current < len(m.readers) {
+		if len(m.starts) <= m.current {
+			m.starts = append(m.starts, m.read)
+			m.src = m.readers[m.current]
+			if m.codec != nil {
+				src, err := m.codec.Decode(m.src)
+				if err == io.EOF {
+					// An empty source, such as an empty gzip file, has nothing to decode.
+					m.current++
+					continue
+				}
+				if err != nil {
+					return 0, m.decodeError(err)
+				}
+				m.src = src
+			}
+			if m.charset != nil {
+				m.src = m.charset.NewDecoder().Reader(m.src)
+			}
+			if m.binary != BinaryAllow {
+				skip, err := m.sniff()
+				if err != nil {
+					return 0, err
+				}
+				if skip {
+					m.current++
+					continue
+				}
+			}
+		}
+		if len(m.pending) > 0 {
+			n := copy(p, m.pending)
+			m.pending = m.pending[n:]
+			m.count(n)
+			return n, nil
+		}
+		n, err := m.src.Read(p)
+		m.count(n)
+		if err == io.EOF {
+			m.current++
+			if n > 0 {
+				return n, nil
+			}
+			continue
+		}
+		return n, err
+	}
+	return 0, io.EOF
+}
+
+// decodeError returns err, returned by the codec decoding the current reader,
+// along with the name of the reader if any.
+func (m *multiSource) decodeError(err error) error {
+	if name := sourceName(m.readers[m.current]); name != "" {
+		return fmt.Errorf("decoding %s: %w", name, err)
+	}
+	return fmt.Errorf("decoding source %d: %w", m.current, err)
+}
+
+// count records n bytes read from the current reader.
+func (m *multiSource) count(n int) {
+	if n == 0 {
+		return
+	}
+	m.read += int64(n)
+	m.last = m.current
+	m.counts[m.current].Add(int64(n))
+}
+
+// locate returns the index of the reader holding the byte at offset off
+// of the combined stream, and the offset of that byte within the reader.
+// It returns -1 if no reader has been read past off yet.
+func (m *multiSource) locate(off int64) (int, int64) {
+	i := sort.Search(len(m.starts), func(i int) bool { return m.starts[i] > off }) - 1
+	if i < 0 {
+		return -1, off
+	}
+	return i, off - m.starts[i]
+}
+
+// trackSources records readers and their current offsets so that
+// they can later be rewound by [Reader.rewind].
+func (r *Reader) trackSources(readers []io.Reader) {
+	sources := make([]io.Reader, 0, len(r.sources)+len(readers))
+	starts := make([]int64, 0, len(r.starts)+len(readers))
+	sources = append(sources, r.sources...)
+	starts = append(starts, r.starts...)
+	for _, rd := range readers {
+		start := int64(-1)
+		if s, ok := seeker(rd); ok {
+			if off, err := s.Seek(0, io.SeekCurrent); err == nil {
+				start = off
+			}
+		}
+		sources = append(sources, rd)
+		starts = append(starts, start)
+	}
+	r.sources, r.starts = sources, starts
+}
+
+// rewind seeks every seekable source back to the offset it had when it was
+// added to r, and rebuilds the combined input stream.
+// Sources that cannot seek are left at their current position.
+func (r *Reader) rewind() error {
+	if len(r.sources) == 0 {
+		return nil
+	}
+	for i, rd := range r.sources {
+		if r.starts[i] < 0 {
+			continue
+		}
+		s, _ := seeker(rd)
+		if _, err := s.Seek(r.starts[i], io.SeekStart); err != nil {
+			return newErrRead(err)
+		}
+	}
+	r.reader = newMultiSource(r.sources)
+	return nil
+}
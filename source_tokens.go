@@ -0,0 +1,153 @@
+package textio
+
+import (
+	"bufio"
+	"io"
+)
+
+// Source returns the tokens of r as a [TokenSource].
+//
+// Tokens are read lazily, one per call to Scan, and go through the same
+// normalization, filtering and error handling as with [Reader.ReadTokens].
+// The optional opts override the configuration of r for the returned source only.
+//
+// The source stops reading once Scan returns false. A source abandoned before then
+// can be stopped with its Close method, so that the sources read ahead with
+// [Reader.SetSchedule] are not left waiting for input; the functions of this package
+// consuming a [TokenSource], such as [Merge] or [Broadcast], close it when they stop
+// before its end.
+func (r *Reader) Source(opts ...ReadOption) TokenSource {
+	return &readerSource{it: r.apply(opts).iter()}
+}
+
+// readerSource is the [TokenSource] returned by [Reader.Source].
+type readerSource struct {
+	it   *tokenIter
+	text string
+	err  error
+	done bool
+}
+
+func (s *readerSource) Scan() bool {
+	if s.done {
+		return false
+	}
+	text, err := s.it.next()
+	if err != nil {
+		s.text, s.done = "", true
+		s.it.close()
+		if err != io.EOF {
+			s.err = err
+		}
+		return false
+	}
+	s.text = text
+	return true
+}
+
+func (s *readerSource) Text() string {
+	return s.text
+}
+
+// Kind returns the kind of the token read by the last call to Scan.
+func (s *readerSource) Kind() TokenKind {
+	return s.it.kind
+}
+
+func (s *readerSource) Err() error {
+	return s.err
+}
+
+// Close stops reading, so that Scan returns false. It must not be called
+// concurrently with Scan.
+func (s *readerSource) Close() error {
+	if !s.done {
+		s.text, s.done = "", true
+		s.it.close()
+	}
+	return nil
+}
+
+// closeSource closes src if it is an [io.Closer], such as the source returned
+// by [Reader.Source], when it is abandoned before its end.
+func closeSource(src TokenSource) {
+	if c, ok := src.(io.Closer); ok {
+		c.Close()
+	}
+}
+
+// readAll returns all the tokens of src.
+func readAll(src TokenSource) ([]string, error) {
+	var tokens []string
+	for src.Scan() {
+		tokens = append(tokens, src.Text())
+	}
+	return tokens, src.Err()
+}
+
+// FromChannel returns a [TokenSource] reading the tokens received on ch until it is closed,
+// so tokens produced elsewhere (message consumers, worker pools...) can go through a
+// [Reader] pipeline with [Reader.SetSource] or be written with [Pipe].
+func FromChannel(ch <-chan string) TokenSource {
+	return &chanSource{ch: ch}
+}
+
+// chanSource is the [TokenSource] returned by [FromChannel].
+type chanSource struct {
+	ch   <-chan string
+	text string
+}
+
+func (s *chanSource) Scan() bool {
+	text, ok := <-s.ch
+	s.text = text
+	return ok
+}
+
+func (s *chanSource) Text() string {
+	return s.text
+}
+
+func (s *chanSource) Err() error {
+	return nil
+}
+
+// FromTokens returns a [TokenSource] replaying tokens, for example to test a pipeline
+// or to process again with new filters a batch returned by [Reader.ReadTokens].
+// The slice is not copied and must not be modified while the source is read.
+func FromTokens(tokens []string) TokenSource {
+	return &sliceSource{tokens: tokens, i: -1}
+}
+
+// sliceSource is the [TokenSource] returned by [FromTokens].
+type sliceSource struct {
+	tokens []string
+	i      int
+}
+
+func (s *sliceSource) Scan() bool {
+	if s.i < len(s.tokens) {
+		s.i++
+	}
+	return s.i < len(s.tokens)
+}
+
+func (s *sliceSource) Text() string {
+	if s.i < 0 || s.i >= len(s.tokens) {
+		return ""
+	}
+	return s.tokens[s.i]
+}
+
+func (s *sliceSource) Err() error {
+	return nil
+}
+
+// FromScanner returns a [TokenSource] reading the tokens of sc, so scanners already
+// configured with custom split functions or buffers can be given to [Reader.SetSource]
+// to benefit from its normalization, filtering and error handling.
+//
+// The scanner must not be used directly while the source is read.
+func FromScanner(sc *bufio.Scanner) TokenSource {
+	return sc
+}
@@ -0,0 +1,72 @@
+package textio
+
+import (
+	"context"
+	"time"
+)
+
+// StallInfo describes a [Reader.StreamTokens] call blocked on a full output
+// channel, see [Reader.SetStallHook].
+type StallInfo struct {
+	// Token is the token waiting to be sent, and Index its index
+	// among all the tokens read (see [TokenInfo]).
+	Token string
+	Index int
+	// Waited is how long the token has been waiting.
+	Waited time.Duration
+	// Queued is the number of tokens in the output channel, and Capacity its capacity.
+	Queued   int
+	Capacity int
+}
+
+// SetStallHook makes [Reader.StreamTokens] call hook when sending a token blocks on the
+// output channel for longer than threshold, and again after each further threshold,
+// to help finding stalled consumers. The hook is called from the streaming goroutine
+// and delays the stream, so it should only log or record the diagnostic, for example:
+//
+//	r.SetStallHook(5*time.Second, func(s textio.StallInfo) {
+//		log.Printf("consumer stalled for %v on token %d (%d/%d queued)", s.Waited, s.Index, s.Queued, s.Capacity)
+//	})
+//
+// A nil hook or a threshold of 0 or less disables the diagnostics.
+func (r *Reader) SetStallHook(threshold time.Duration, hook func(StallInfo)) {
+	r.stallAfter = threshold
+	r.onStall = hook
+}
+
+// send sends token to out, reporting stalls to the hook of r.
+func (r *Reader) send(ctx context.Context, out chan string, token string, index int) error {
+	if r.onStall == nil || r.stallAfter <= 0 {
+		select {
+		case out <- token:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	select {
+	case out <- token:
+		return nil
+	default:
+	}
+	start := time.Now()
+	ticker := time.NewTicker(r.stallAfter)
+	defer ticker.Stop()
+	for {
+		select {
+		case out <- token:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			r.onStall(StallInfo{
+				Token:    token,
+				Index:    index,
+				Waited:   time.Since(start),
+				Queued:   len(out),
+				Capacity: cap(out),
+			})
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package textio
+
+import (
+	"expvar"
+	"sync/atomic"
+)
+
+// Stats holds counters of the work done by a [Reader] since its input was last set.
+type Stats struct {
+	// Tokens is the number of tokens scanned, Accepted and Rejected
+	// the number of them that passed or failed the filter.
+	Tokens   int64
+	Accepted int64
+	Rejected int64
+	// Bytes is the number of bytes read from all the sources.
+	Bytes int64
+	// Sources holds the accounting of each source, in order.
+	Sources []SourceStats
+}
+
+// SourceStats is the accounting of a single source of a [Reader].
+type SourceStats struct {
+	// Name is the name of the source, empty if it has none (see [TokenInfo]).
+	Name string
+	// Bytes is the number of bytes read from the source.
+	Bytes int64
+}
+
+// tokenStats holds the token counters of [Stats].
+type tokenStats struct {
+	tokens, accepted, rejected atomic.Int64
+}
+
+func (s *tokenStats) add(o *tokenStats) {
+	s.tokens.Add(o.tokens.Load())
+	s.accepted.Add(o.accepted.Load())
+	s.rejected.Add(o.rejected.Load())
+}
+
+// Stats returns the counters of r. It is safe to call while r is reading,
+// for example to report progress.
+//
+// Bytes are counted as they are read from the sources, including
+// the bytes buffered ahead of the last token.
+func (r *Reader) Stats() Stats {
+	ms := r.input()
+	if ms == nil {
+		return Stats{}
+	}
+	st := Stats{
+		Tokens:   ms.stats.tokens.Load(),
+		Accepted: ms.stats.accepted.Load(),
+		Rejected: ms.stats.rejected.Load(),
+		Sources:  make([]SourceStats, len(ms.readers)),
+	}
+	for i, rd := range ms.readers {
+		n := ms.counts[i].Load()
+		st.Sources[i] = SourceStats{Name: sourceName(rd), Bytes: n}
+		st.Bytes += n
+	}
+	return st
+}
+
+// Var returns an [expvar.Var] reporting the [Stats] of r as JSON,
+// to be published under a name of the caller's choice.
+func (r *Reader) Var() expvar.Var {
+	return expvar.Func(func() any { return r.Stats() })
+}
+
+// PublishExpvar publishes the [Stats] of r with [expvar.Publish] under the name
+// prefix + ".reader", so services get live token throughput at /debug/vars.
+// Like [expvar.Publish], it panics if the name is already in use.
+func (r *Reader) PublishExpvar(prefix string) {
+	expvar.Publish(prefix+".reader", r.Var())
+}
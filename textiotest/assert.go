@@ -0,0 +1,77 @@
+package textiotest
+
+import (
+	"bufio"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// UpdateGolden makes [AssertGolden] rewrite golden files with the tokens
+// it is given instead of comparing them. Tests usually bind it to a flag:
+//
+//	flag.BoolVar(&textiotest.UpdateGolden, "update", false, "update golden files")
+var UpdateGolden bool
+
+// AssertTokens reports a test error if got and want differ,
+// describing the first mismatching token.
+func AssertTokens(t testing.TB, got, want []string) {
+	t.Helper()
+	for i := 0; i < len(got) && i < len(want); i++ {
+		if got[i] != want[i] {
+			t.Errorf("token[%d] = %q, want %q", i, got[i], want[i])
+			return
+		}
+	}
+	if len(got) != len(want) {
+		t.Errorf("got %d tokens : %q, want %d : %q", len(got), got, len(want), want)
+	}
+}
+
+// AssertGolden compares got with the tokens stored in the golden file at path,
+// and reports a test error if they differ.
+//
+// Golden files hold one Go-quoted token per line, so tokens containing
+// newlines or control characters are stored unambiguously.
+// If [UpdateGolden] is set, the file is written with got instead.
+func AssertGolden(t testing.TB, got []string, path string) {
+	t.Helper()
+	if UpdateGolden {
+		var sb strings.Builder
+		for _, tok := range got {
+			sb.WriteString(strconv.Quote(tok))
+			sb.WriteByte('\n')
+		}
+		if err := os.WriteFile(path, []byte(sb.String()), 0o644); err != nil {
+			t.Fatalf("writing golden file %s: %v", path, err)
+		}
+		return
+	}
+
+	want, err := ReadGolden(path)
+	if err != nil {
+		t.Fatalf("reading golden file %s: %v", path, err)
+	}
+	AssertTokens(t, got, want)
+}
+
+// ReadGolden returns the tokens stored in the golden file at path.
+func ReadGolden(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var tokens []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		tok, err := strconv.Unquote(scanner.Text())
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, tok)
+	}
+	return tokens, scanner.Err()
+}
@@ -0,0 +1,96 @@
+// Package textiotest provides utilities for testing code built on [textio].
+package textiotest
+
+import (
+	"context"
+	"sync"
+
+	"github.com/JFinlayM/textio"
+)
+
+// Step is one scripted event replayed by a [FakeTokenReader]:
+// either a token, or an error if Err is not nil.
+type Step struct {
+	Token string
+	Err   error
+}
+
+// FakeTokenReader is a test double implementing [textio.TokenReaderStreamer].
+//
+// It replays its scripted steps on every call: tokens are returned (or streamed)
+// in order until an error step is reached, in which case the tokens replayed so
+// far are returned along with that error. The [textio.ReadOption] values given
+// to the methods are ignored.
+//
+// A FakeTokenReader is safe for concurrent use.
+type FakeTokenReader struct {
+	mu    sync.Mutex
+	steps []Step
+	calls int
+}
+
+var _ textio.TokenReaderStreamer = (*FakeTokenReader)(nil)
+
+// NewFakeTokenReader creates a [FakeTokenReader] replaying tokens.
+func NewFakeTokenReader(tokens ...string) *FakeTokenReader {
+	f := &FakeTokenReader{}
+	for _, tok := range tokens {
+		f.steps = append(f.steps, Step{Token: tok})
+	}
+	return f
+}
+
+// NewFakeTokenReaderSteps creates a [FakeTokenReader] replaying steps.
+func NewFakeTokenReaderSteps(steps ...Step) *FakeTokenReader {
+	return &FakeTokenReader{steps: append([]Step(nil), steps...)}
+}
+
+// WithError appends an error step to the script of f and returns f.
+func (f *FakeTokenReader) WithError(err error) *FakeTokenReader {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.steps = append(f.steps, Step{Err: err})
+	return f
+}
+
+// Calls returns the number of calls made to ReadTokens and StreamTokens.
+func (f *FakeTokenReader) Calls() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls
+}
+
+func (f *FakeTokenReader) script() []Step {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	return f.steps
+}
+
+// ReadTokens returns the scripted tokens up to the first error step.
+func (f *FakeTokenReader) ReadTokens(opts ...textio.ReadOption) ([]string, error) {
+	var tokens []string
+	for _, step := range f.script() {
+		if step.Err != nil {
+			return tokens, step.Err
+		}
+		tokens = append(tokens, step.Token)
+	}
+	return tokens, nil
+}
+
+// StreamTokens sends the scripted tokens to out up to the first error step.
+// It returns ctx.Err() if ctx is done before all tokens are sent.
+func (f *FakeTokenReader) StreamTokens(ctx context.Context, out chan string, opts ...textio.ReadOption) error {
+	for _, step := range f.script() {
+		if step.Err != nil {
+			return step.Err
+		}
+		select {
+		case out <- step.Token:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
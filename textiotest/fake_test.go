@@ -0,0 +1,32 @@
+package textiotest
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestFakeTokenReader(t *testing.T) {
+	errBoom := errors.New("boom")
+	f := NewFakeTokenReader("a", "b").WithError(errBoom)
+
+	tokens, err := f.ReadTokens()
+	if !errors.Is(err, errBoom) {
+		t.Fatalf("ReadTokens() error = %v, want %v", err, errBoom)
+	}
+	if len(tokens) != 2 || tokens[0] != "a" || tokens[1] != "b" {
+		t.Errorf("got tokens %v, want [a b]", tokens)
+	}
+
+	out := make(chan string, 10)
+	if err := f.StreamTokens(context.Background(), out); !errors.Is(err, errBoom) {
+		t.Fatalf("StreamTokens() error = %v, want %v", err, errBoom)
+	}
+	if len(out) != 2 {
+		t.Errorf("got %d streamed tokens, want 2", len(out))
+	}
+
+	if f.Calls() != 2 {
+		t.Errorf("Calls() = %d, want 2", f.Calls())
+	}
+}
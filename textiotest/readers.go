@@ -0,0 +1,71 @@
+package textiotest
+
+import (
+	"io"
+	"time"
+)
+
+// ErrorReader reads from R and fails with Err once N bytes have been read.
+//
+// It is useful to check how code handles I/O errors occurring
+// in the middle of an input.
+type ErrorReader struct {
+	R   io.Reader
+	N   int64
+	Err error
+}
+
+// NewErrorReader returns an [ErrorReader] reading n bytes from r before failing with err.
+func NewErrorReader(r io.Reader, n int64, err error) *ErrorReader {
+	return &ErrorReader{R: r, N: n, Err: err}
+}
+
+func (e *ErrorReader) Read(p []byte) (int, error) {
+	if e.N <= 0 {
+		return 0, e.Err
+	}
+	if int64(len(p)) > e.N {
+		p = p[:e.N]
+	}
+	n, err := e.R.Read(p)
+	e.N -= int64(n)
+	if err == io.EOF && e.N > 0 {
+		return n, io.EOF
+	}
+	if err == nil && e.N <= 0 {
+		return n, e.Err
+	}
+	return n, err
+}
+
+// ChunkReader reads from R at most Size bytes per call, waiting Delay before
+// each read.
+//
+// Small chunks make token and delimiter boundaries fall across buffer
+// refills, which exercises split functions the way slow networks do.
+type ChunkReader struct {
+	R     io.Reader
+	Size  int
+	Delay time.Duration
+}
+
+// NewChunkReader returns a [ChunkReader] reading at most size bytes per call from r.
+func NewChunkReader(r io.Reader, size int) *ChunkReader {
+	return &ChunkReader{R: r, Size: size}
+}
+
+// NewSlowReader returns a [ChunkReader] reading at most size bytes per call
+// from r and waiting delay before each read.
+func NewSlowReader(r io.Reader, size int, delay time.Duration) *ChunkReader {
+	return &ChunkReader{R: r, Size: size, Delay: delay}
+}
+
+func (c *ChunkReader) Read(p []byte) (int, error) {
+	if c.Delay > 0 {
+		time.Sleep(c.Delay)
+	}
+	if c.Size > 0 && len(p) > c.Size {
+		p = p[:c.Size]
+	}
+	return c.R.Read(p)
+}
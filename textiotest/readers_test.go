@@ -0,0 +1,47 @@
+package textiotest
+
+import (
+	"errors"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/JFinlayM/textio"
+)
+
+func TestChunkReader(t *testing.T) {
+	r := textio.NewReader()
+	r.SetReaders(NewChunkReader(strings.NewReader("one  two   three"), 3))
+	r.SetDelimiter(textio.NewDelimiter().WithTokenRegexpFromString(`\s+`))
+
+	tokens, err := r.ReadTokens()
+	if err != nil {
+		t.Fatalf("ReadTokens() error = %v", err)
+	}
+	AssertTokens(t, tokens, []string{"one", "two", "three"})
+}
+
+func TestErrorReader(t *testing.T) {
+	errBoom := errors.New("boom")
+	r := NewErrorReader(strings.NewReader("hello world"), 5, errBoom)
+
+	b, err := io.ReadAll(r)
+	if !errors.Is(err, errBoom) {
+		t.Fatalf("ReadAll() error = %v, want %v", err, errBoom)
+	}
+	if string(b) != "hello" {
+		t.Errorf("ReadAll() = %q, want %q", b, "hello")
+	}
+}
+
+func TestAssertGolden(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tokens.golden")
+	tokens := []string{"a", "multi\nline", ""}
+
+	UpdateGolden = true
+	AssertGolden(t, tokens, path)
+	UpdateGolden = false
+
+	AssertGolden(t, tokens, path)
+}
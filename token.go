@@ -0,0 +1,39 @@
+package textio
+
+import "github.com/JFinlayM/textio/internal/core"
+
+// TokenInfo describes a token read by a [Reader].
+//
+// It is given to [FilterFuncInfo] and [NormalizeFuncInfo] functions
+// so that they can take the position of the token into account,
+// for example to require the first token to be a header.
+type TokenInfo struct {
+	// Text is the token after normalization. It equals Raw in normalization functions.
+	Text string
+	// Raw is the token as found in the input, before normalization.
+	Raw string
+	// Index is the index of the token among all the tokens read, including rejected ones.
+	Index int
+	// Offset is the byte offset of the token within its source.
+	Offset int64
+	// Source is the name of the source the token was read from,
+	// or an empty string if the source has no name.
+	Source string
+	// Lang is the language of the token, as found by the detector
+	// set with [Reader.SetLanguageDetector], or an empty string.
+	Lang string
+}
+
+// TokenKind tells apart the tokens read by a [Reader].
+type TokenKind = core.TokenKind
+
+const (
+	// KindContent is a token of the input content.
+	KindContent = core.KindContent
+	// KindDelimiter is a delimiter found in the input,
+	// emitted when [Delimiter.SetEmitDelimiters] is set.
+	KindDelimiter = core.KindDelimiter
+)
+
+// Token is a token read by a [Reader] along with its kind.
+type Token = core.Token
@@ -0,0 +1,44 @@
+package textio
+
+import (
+	"context"
+	"io"
+	"iter"
+)
+
+// Tokens returns an iterator over the tokens of r, for use with range-over-func:
+//
+//	for token, err := range r.Tokens(ctx) {
+//		if err != nil {
+//			return err
+//		}
+//		...
+//	}
+//
+// Tokens go through the same delimiter, normalization and filtering pipeline as with
+// [Reader.StreamTokens]. An error is yielded once, with an empty token, and ends the
+// iteration; the error of a cancelled ctx is yielded before the next token.
+// Breaking out of the loop stops reading.
+// The optional opts override the configuration of r for this iteration only.
+func (r *Reader) Tokens(ctx context.Context, opts ...ReadOption) iter.Seq2[string, error] {
+	return func(yield func(string, error) bool) {
+		it := r.apply(opts).iter()
+		defer it.close()
+		for {
+			token, err := it.next()
+			if err == io.EOF {
+				return
+			}
+			if err == nil {
+				err = ctx.Err()
+			}
+			if err != nil {
+				yield("", err)
+				return
+			}
+			if !yield(token, nil) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package textio
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// URLOption configures [ReaderCloser.FromURL].
+type URLOption func(*urlSource)
+
+// WithHTTPClient returns a [URLOption] sending the requests with c instead of
+// [http.DefaultClient], for timeouts, proxies or authentication.
+func WithHTTPClient(c *http.Client) URLOption {
+	return func(u *urlSource) {
+		u.client = c
+	}
+}
+
+// WithRetries returns a [URLOption] retrying a failed request up to n more times,
+// waiting backoff before the first retry and twice as long before each next one.
+// Network errors and responses with status 429 or 5xx are retried. There is no
+// retry by default.
+func WithRetries(n int, backoff time.Duration) URLOption {
+	return func(u *urlSource) {
+		u.retries = max(n, 0)
+		u.backoff = backoff
+	}
+}
+
+type urlSource struct {
+	client  *http.Client
+	retries int
+	backoff time.Duration
+}
+
+// [FromURL] returns a shallow copy of the [ReaderCloser] with a new reader from the
+// body of the response to a GET request of url. This discards and closes the previously
+// set readers. The body is streamed as it is read, and closed by [ReaderCloser.Close].
+// Tokens are reported with url as their source name (see [TokenInfo]).
+//
+// ctx applies to the request and to the reading of the body. A request failing after
+// the retries set with [WithRetries], or answered with a status other than 2xx, is
+// reported with [ErrOpen].
+//
+// The original [ReaderCloser] is not modified.
+func (rc *ReaderCloser) FromURL(ctx context.Context, url string, opts ...URLOption) (*ReaderCloser, error) {
+	u := &urlSource{client: http.DefaultClient}
+	for _, opt := range opts {
+		opt(u)
+	}
+	body, err := u.get(ctx, url)
+	if err != nil {
+		return nil, newErrOpen(err)
+	}
+	newR := *rc
+	newR.SetReaders(&namedBody{ReadCloser: body, name: url})
+	return &newR, nil
+}
+
+// get returns the body of the response to a GET request of url, retrying as configured.
+func (u *urlSource) get(ctx context.Context, url string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	wait := u.backoff
+	for attempt := 0; ; attempt++ {
+		resp, err := u.client.Do(req)
+		retry := err != nil
+		if err == nil {
+			if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+				return resp.Body, nil
+			}
+			resp.Body.Close()
+			err = fmt.Errorf("GET %s: %s", url, resp.Status)
+			retry = resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+		}
+		if !retry || attempt >= u.retries || ctx.Err() != nil {
+			return nil, err
+		}
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		wait *= 2
+	}
+}
+
+// namedBody is a response body with the name of the URL it was read from.
+type namedBody struct {
+	io.ReadCloser
+	name string
+}
+
+func (b *namedBody) Name() string {
+	return b.name
+}
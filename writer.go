@@ -0,0 +1,30 @@
+package textio
+
+import (
+	"io"
+
+	"github.com/JFinlayM/textio/writers"
+)
+
+// [Writer] writes tokens to an [io.Writer], separated according to its [Delimiter].
+//
+// Content tokens are separated as with [Join]: the joiner of the delimiter is written
+// between two consecutive content tokens. Tokens of kind [KindDelimiter] are written
+// as is and replace the joiner, so the output of a [Reader] emitting its delimiters
+// (see [Delimiter.SetEmitDelimiters]) is reproduced exactly.
+// See package [github.com/JFinlayM/textio/writers] for its methods.
+type Writer = writers.Writer
+
+// NewWriter creates a [Writer] writing to w and the optional more writers with
+// the [DefaultDelimiter], so tokens are written one per line.
+// By default, it fails on write errors.
+func NewWriter(w io.Writer, more ...io.Writer) *Writer {
+	return writers.NewWriter(w, more...)
+}
+
+// FlushPolicy decides when a [Writer] flushes the tokens it buffers,
+// see [Writer.SetFlushPolicy].
+type FlushPolicy = writers.FlushPolicy
+
+// WriterStats holds counters of the work done by a [Writer].
+type WriterStats = writers.WriterStats
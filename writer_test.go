@@ -0,0 +1,425 @@
+package textio
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"expvar"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+)
+
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("broken pipe")
+}
+
+func TestWriter(t *testing.T) {
+	var sb strings.Builder
+	w := NewWriter(&sb)
+	for _, s := range []string{"a", "b", "c"} {
+		if err := w.WriteString(s); err != nil {
+			t.Fatalf("WriteString() error = %v", err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if got := sb.String(); got != "a\nb\nc" {
+		t.Errorf("got %q, want %q", got, "a\nb\nc")
+	}
+
+	w = NewWriter(failingWriter{})
+	w.WriteString("a")
+	if err := w.Flush(); !errors.Is(err, ErrWrite) {
+		t.Errorf("Flush() error = %v, want %v", err, ErrWrite)
+	}
+}
+
+func TestPipe_Reconstruction(t *testing.T) {
+	input := "  hello   world\r\nfoo \n"
+	d := WordsPreset()
+	d.SetEmitDelimiters(true)
+	r := NewReader().FromString(input).WithDelimiter(d).WithNormalizer(strings.ToUpper)
+
+	var sb strings.Builder
+	w := NewWriter(&sb)
+	w.SetDelimiter(d)
+	n, err := Pipe(w, r.Source())
+	if err != nil {
+		t.Fatalf("Pipe() error = %v", err)
+	}
+	if n != 7 {
+		t.Errorf("Pipe() = %d tokens, want %d", n, 7)
+	}
+	if got := sb.String(); got != strings.ToUpper(input) {
+		t.Errorf("got %q, want %q", got, strings.ToUpper(input))
+	}
+}
+
+func TestTeePartition(t *testing.T) {
+	var all, short, long strings.Builder
+	isShort := func(s string) bool { return len(s) <= 3 }
+	dst := Tee(NewWriter(&all), PartitionTo(isShort, NewWriter(&short), NewWriter(&long)))
+
+	src := NewReader().FromString("go\nrust\nc\nhaskell").Source()
+	if _, err := Pipe(dst, src); err != nil {
+		t.Fatalf("Pipe() error = %v", err)
+	}
+
+	tests := []struct {
+		got, want string
+	}{
+		{all.String(), "go\nrust\nc\nhaskell"},
+		{short.String(), "go\nc"},
+		{long.String(), "rust\nhaskell"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("got %q, want %q", tt.got, tt.want)
+		}
+	}
+}
+
+func TestWriter_Gzip(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	if err := w.SetGzip(gzip.BestCompression); err != nil {
+		t.Fatalf("SetGzip() error = %v", err)
+	}
+	w.WriteString("hello")
+	w.WriteString("world")
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	// A sync flush makes the data written so far readable.
+	zr, err := gzip.NewReader(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("gzip.NewReader() error = %v", err)
+	}
+	partial := make([]byte, 11)
+	if _, err := io.ReadFull(zr, partial); err != nil || string(partial) != "hello\nworld" {
+		t.Errorf("read %q, %v after Flush, want %q", partial, err, "hello\nworld")
+	}
+
+	w.WriteString("!")
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	zr, err = gzip.NewReader(&buf)
+	if err != nil {
+		t.Fatalf("gzip.NewReader() error = %v", err)
+	}
+	tokens, err := NewReader().FromBytes(mustReadAll(t, zr)).ReadTokens()
+	if err != nil {
+		t.Fatalf("ReadTokens() error = %v", err)
+	}
+	if strings.Join(tokens, "|") != "hello|world|!" {
+		t.Errorf("got tokens %q", tokens)
+	}
+
+	if err := NewWriter(&buf).SetGzip(42); err == nil {
+		t.Errorf("SetGzip(42) error = nil")
+	}
+}
+
+func mustReadAll(t *testing.T, r io.Reader) []byte {
+	t.Helper()
+	b, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+	return b
+}
+
+func TestWriter_FormatJSONArray(t *testing.T) {
+	tests := []struct {
+		tokens []string
+		want   string
+	}{
+		{nil, `[]`},
+		{[]string{"a"}, `["a"]`},
+		{[]string{"say \"hi\"", "tab\there", "<b>&é"}, `["say \"hi\"","tab\there","<b>&é"]`},
+	}
+	for _, tt := range tests {
+		var sb strings.Builder
+		w := NewWriter(&sb)
+		w.FormatJSONArray()
+		for _, tok := range tt.tokens {
+			w.WriteString(tok)
+			w.WriteToken(Token{Text: "\n", Kind: KindDelimiter})
+		}
+		if err := w.Close(); err != nil {
+			t.Fatalf("Close() error = %v", err)
+		}
+		if sb.String() != tt.want {
+			t.Errorf("got %s, want %s", sb.String(), tt.want)
+		}
+		var decoded []string
+		if err := json.Unmarshal([]byte(sb.String()), &decoded); err != nil || len(decoded) != len(tt.tokens) {
+			t.Errorf("json.Unmarshal(%s) = %q, %v", sb.String(), decoded, err)
+		}
+	}
+}
+
+func TestWriter_FormatJSONL(t *testing.T) {
+	var sb strings.Builder
+	w := NewWriter(&sb)
+	w.FormatJSONL("word")
+	w.WriteString(`a "quoted" word`)
+	w.WriteToken(Token{Text: " ", Kind: KindDelimiter})
+	if err := w.WriteMap(map[string]string{"level": "info", "msg": "<ok>"}); err != nil {
+		t.Fatalf("WriteMap() error = %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	want := `{"word":"a \"quoted\" word"}` + "\n" + `{"level":"info","msg":"<ok>"}` + "\n"
+	if sb.String() != want {
+		t.Errorf("got %q, want %q", sb.String(), want)
+	}
+
+	if err := NewWriter(&sb).WriteMap(nil); !errors.Is(err, ErrWrite) {
+		t.Errorf("WriteMap() error = %v, want %v", err, ErrWrite)
+	}
+}
+
+func TestWriter_WriteRecord(t *testing.T) {
+	records := [][]string{
+		{"name", "comment"},
+		{"plain", "with, comma"},
+		{`say "hi"`, "multi\nline"},
+		{"", " padded "},
+	}
+
+	var sb strings.Builder
+	w := NewWriter(&sb)
+	for _, rec := range records {
+		if err := w.WriteRecord(rec); err != nil {
+			t.Fatalf("WriteRecord() error = %v", err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	want := "name,comment\nplain,\"with, comma\"\n\"say \"\"hi\"\"\",\"multi\nline\"\n,\" padded \"\n"
+	if sb.String() != want {
+		t.Errorf("got %q, want %q", sb.String(), want)
+	}
+	got, err := csv.NewReader(strings.NewReader(sb.String())).ReadAll()
+	if err != nil {
+		t.Fatalf("csv ReadAll() error = %v", err)
+	}
+	if fmt.Sprint(got) != fmt.Sprint(records) {
+		t.Errorf("csv ReadAll() = %q, want %q", got, records)
+	}
+}
+
+func TestPublishExpvar(t *testing.T) {
+	r := NewReader().FromString("a\nbb\nccc")
+	var sb strings.Builder
+	w := NewWriter(&sb)
+	// Names must be unique across runs of the test.
+	prefix := fmt.Sprintf("test_publish_%d", time.Now().UnixNano())
+	r.PublishExpvar(prefix)
+	w.PublishExpvar(prefix)
+
+	if _, err := Pipe(w, r.Source()); err != nil {
+		t.Fatalf("Pipe() error = %v", err)
+	}
+
+	var rs Stats
+	if err := json.Unmarshal([]byte(expvar.Get(prefix+".reader").String()), &rs); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if rs.Tokens != 3 || rs.Accepted != 3 || rs.Bytes != 8 {
+		t.Errorf("reader stats = %+v, want 3 tokens and 8 bytes", rs)
+	}
+
+	var ws WriterStats
+	if err := json.Unmarshal([]byte(expvar.Get(prefix+".writer").String()), &ws); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+	if ws.Tokens != 3 || ws.Bytes != 8 {
+		t.Errorf("writer stats = %+v, want 3 tokens and 8 bytes", ws)
+	}
+}
+
+func TestCodec_RoundTrip(t *testing.T) {
+	for _, name := range []string{"gzip", "zstd", "none"} {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			w := NewWriter(&buf)
+			if err := w.SetCodec(name); err != nil {
+				t.Fatalf("Writer.SetCodec() error = %v", err)
+			}
+			for _, s := range []string{"alpha", "beta", "gamma"} {
+				w.WriteString(s)
+			}
+			if err := w.Close(); err != nil {
+				t.Fatalf("Close() error = %v", err)
+			}
+
+			// Two encoded sources are decoded one after the other.
+			r := NewReader()
+			if err := r.SetCodec(name); err != nil {
+				t.Fatalf("Reader.SetCodec() error = %v", err)
+			}
+			r.SetBinaryPolicy(BinaryFail)
+			r.SetReaders(bytes.NewReader(buf.Bytes()), bytes.NewReader(buf.Bytes()))
+			tokens, err := r.ReadTokens()
+			if err != nil {
+				t.Fatalf("ReadTokens() error = %v", err)
+			}
+			if got := strings.Join(tokens, "|"); got != "alpha|beta|gammaalpha|beta|gamma" {
+				t.Errorf("ReadTokens() = %q", got)
+			}
+		})
+	}
+
+	// Empty sources are skipped, and corrupt ones are read errors.
+	gz := func(s string) *bytes.Reader {
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		zw.Write([]byte(s))
+		zw.Close()
+		return bytes.NewReader(buf.Bytes())
+	}
+	r := NewReader()
+	if err := r.SetCodec("gzip"); err != nil {
+		t.Fatalf("SetCodec() error = %v", err)
+	}
+	tokens, err := r.WithReaders(gz("a\nb\n"), strings.NewReader(""), gz("c\nd")).ReadTokens()
+	if err != nil {
+		t.Fatalf("ReadTokens() error = %v", err)
+	}
+	if got := strings.Join(tokens, "|"); got != "a|b|c|d" {
+		t.Errorf("ReadTokens() = %q, want %q", got, "a|b|c|d")
+	}
+	_, err = r.WithReaders(gz("a\n"), Named("bad.gz", strings.NewReader("this is not gzip data"))).ReadTokens()
+	if !errors.Is(err, ErrRead) || !errors.Is(err, gzip.ErrHeader) || !strings.Contains(err.Error(), "bad.gz") {
+		t.Errorf("ReadTokens() error = %v, want %v wrapping %v for bad.gz", err, ErrRead, gzip.ErrHeader)
+	}
+
+	if err := NewReader().SetCodec("lz4"); err == nil {
+		t.Errorf("SetCodec(%q) error = nil", "lz4")
+	}
+	if _, ok := CodecByName("gzip"); !ok {
+		t.Errorf("CodecByName(%q) not found", "gzip")
+	}
+}
+
+func TestWriter_Sinks(t *testing.T) {
+	var a, b strings.Builder
+	w := NewWriter(&a, failingWriter{}, &b)
+	w.FailOnError = false
+	w.SetNormalizer(strings.ToUpper)
+	if err := w.WriteTokens([]string{"x", "y"}); err != nil {
+		t.Fatalf("WriteTokens() error = %v", err)
+	}
+
+	in := make(chan string, 2)
+	in <- "z"
+	close(in)
+	if err := w.ConsumeTokens(context.Background(), in); err != nil {
+		t.Fatalf("ConsumeTokens() error = %v", err)
+	}
+	if a.String() != "X\nY\nZ" || b.String() != a.String() {
+		t.Errorf("sinks = %q and %q, want %q", a.String(), b.String(), "X\nY\nZ")
+	}
+
+	w = NewWriter(&a, failingWriter{})
+	w.WriteString("a")
+	if err := w.Flush(); !errors.Is(err, ErrWrite) {
+		t.Errorf("Flush() error = %v, want %v", err, ErrWrite)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := NewWriter(io.Discard).ConsumeTokens(ctx, make(chan string)); err != context.Canceled {
+		t.Errorf("ConsumeTokens() error = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestWriter_SetFlushPolicy(t *testing.T) {
+	buf := &lockedBuffer{}
+	w := NewWriter(buf)
+	w.SetFlushPolicy(FlushPolicy{Tokens: 2})
+	w.WriteString("a")
+	if buf.String() != "" {
+		t.Errorf("output after 1 token = %q, want none", buf.String())
+	}
+	w.WriteString("b")
+	if buf.String() != "a\nb" {
+		t.Errorf("output after 2 tokens = %q, want %q", buf.String(), "a\nb")
+	}
+
+	buf = &lockedBuffer{}
+	w = NewWriter(buf)
+	w.SetFlushPolicy(FlushPolicy{Bytes: 8192})
+	for range 1000 {
+		w.WriteString("12345")
+	}
+	if n := len(buf.String()); n != 0 {
+		t.Errorf("%d bytes flushed below the byte threshold", n)
+	}
+	w.WriteString(strings.Repeat("x", 3000))
+	if n := len(buf.String()); n == 0 {
+		t.Error("nothing flushed past the byte threshold")
+	}
+
+	buf = &lockedBuffer{}
+	w = NewWriter(buf)
+	w.SetFlushPolicy(FlushPolicy{Interval: time.Millisecond})
+	w.WriteString("tick")
+	deadline := time.Now().Add(time.Second)
+	for buf.String() != "tick" && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if buf.String() != "tick" {
+		t.Errorf("output = %q, want it flushed by the interval", buf.String())
+	}
+	if err := w.Close(); err != nil {
+		t.Errorf("Close() error = %v", err)
+	}
+}
+
+func TestConverse(t *testing.T) {
+	respond := func(s string) (string, error) {
+		switch s {
+		case "quit":
+			return "bye", ErrEndConversation
+		case "boom":
+			return "", errors.New("unknown command")
+		}
+		return strings.ToUpper(s), nil
+	}
+	converse := func(input string, opts ...ConverseOption) (string, error) {
+		var sb strings.Builder
+		err := Converse(context.Background(), NewReader().FromString(input).Source(), respond, NewWriter(&sb), opts...)
+		return sb.String(), err
+	}
+
+	got, err := converse("hi\nboom\nthere\nquit\nignored", WithRespondErrors(RespondReply))
+	if err != nil || got != "HI\nunknown command\nTHERE\nbye\n" {
+		t.Errorf("Converse(RespondReply) = %q, %v", got, err)
+	}
+	got, err = converse("hi\nboom\nthere", WithRespondErrors(RespondSkip))
+	if err != nil || got != "HI\nTHERE\n" {
+		t.Errorf("Converse(RespondSkip) = %q, %v", got, err)
+	}
+	got, err = converse("hi\nboom\nthere")
+	if err == nil || got != "HI\n" {
+		t.Errorf("Converse() = %q, %v, want the error of respond", got, err)
+	}
+}
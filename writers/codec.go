@@ -0,0 +1,19 @@
+package writers
+
+import "github.com/JFinlayM/textio/internal/core"
+
+// SetCodec makes the [Writer] encode its output with the codec registered under name.
+// It must be called before writing any token, and [Writer.Close] must then be called
+// to complete the encoded stream.
+func (w *Writer) SetCodec(name string) error {
+	c, err := core.LookupCodec(name)
+	if err != nil {
+		return err
+	}
+	enc, err := c.Encode(w.out)
+	if err != nil {
+		return err
+	}
+	w.setEncoder(enc)
+	return nil
+}
@@ -0,0 +1,12 @@
+package writers
+
+import "github.com/JFinlayM/textio/internal/core"
+
+func newErrWrite(token string, index int, err error) error {
+	re := core.NewReaderError(3)
+	re.Kind = ErrWrite
+	re.Token = token
+	re.Index = index
+	re.Err = err
+	return re
+}
@@ -0,0 +1,73 @@
+package writers
+
+import (
+	"bufio"
+	"io"
+	"time"
+)
+
+// FlushPolicy decides when a [Writer] flushes the tokens it buffers, so small tokens are
+// not written one system call at a time while latency remains bounded. Buffered tokens
+// are flushed as soon as any of the thresholds set is reached. Data is also flushed
+// when the buffer is full, and by [Writer.Flush] and [Writer.Close].
+//
+// The zero FlushPolicy is the default: tokens are only flushed when the buffer is full.
+type FlushPolicy struct {
+	// Tokens flushes after that many tokens, if positive.
+	Tokens int
+	// Bytes flushes once that many bytes are buffered, if positive. The buffer
+	// is enlarged as needed to hold them.
+	Bytes int
+	// Interval flushes the buffered tokens every Interval, if positive,
+	// even while no token is written.
+	Interval time.Duration
+}
+
+// SetFlushPolicy sets when the [Writer] flushes the tokens it buffers.
+// It must be called before writing any token, and after [Writer.SetWriters] and
+// [Writer.SetCodec]. With an Interval, flushes happen in the background, writes
+// block meanwhile, and [Writer.Close] must be called to stop them.
+func (w *Writer) SetFlushPolicy(p FlushPolicy) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.stopTicker()
+	w.policy = p
+	if p.Bytes > w.w.Size() && w.w.Buffered() == 0 {
+		var sink io.Writer = w.out
+		if w.enc != nil {
+			sink = w.enc
+		}
+		w.w = bufio.NewWriterSize(sink, p.Bytes)
+	}
+	if p.Interval > 0 {
+		w.stop = make(chan struct{})
+		go w.flushEvery(p.Interval, w.stop)
+	}
+}
+
+// flushEvery flushes w every d until stop is closed.
+func (w *Writer) flushEvery(d time.Duration, stop chan struct{}) {
+	ticker := time.NewTicker(d)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			w.mu.Lock()
+			if w.w.Buffered() > 0 {
+				// Errors are sticky, and reported by the next write.
+				w.flush()
+			}
+			w.mu.Unlock()
+		case <-stop:
+			return
+		}
+	}
+}
+
+// stopTicker stops the interval flushes, if any.
+func (w *Writer) stopTicker() {
+	if w.stop != nil {
+		close(w.stop)
+		w.stop = nil
+	}
+}
@@ -0,0 +1,116 @@
+package writers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+)
+
+// writerFormat is the output format of a [Writer].
+type writerFormat int
+
+const (
+	formatPlain writerFormat = iota
+	formatJSONArray
+	formatJSONL
+)
+
+// FormatJSONArray makes the [Writer] write the tokens as the elements of a JSON array
+// of strings, so pipelines can feed web APIs directly. Tokens are escaped and written
+// as they come; [Writer.Close] must be called to close the array.
+// Delimiter tokens are not written.
+func (w *Writer) FormatJSONArray() {
+	w.format = formatJSONArray
+}
+
+// FormatJSONL makes the [Writer] write each token as a JSON object on its own line
+// (JSON Lines), holding the token in the given field, "token" if field is empty.
+// Records can be written with [Writer.WriteMap]. Delimiter tokens are not written.
+func (w *Writer) FormatJSONL(field string) {
+	if field == "" {
+		field = "token"
+	}
+	w.format = formatJSONL
+	w.field = field
+}
+
+// WriteMap writes m as a JSON object on its own line. It requires the
+// JSON Lines format, see [Writer.FormatJSONL].
+func (w *Writer) WriteMap(m map[string]string) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.format != formatJSONL {
+		return newErrWrite("", w.n, errors.New("WriteMap requires the JSON Lines format"))
+	}
+	if err := w.write(jsonString(m) + "\n"); err != nil {
+		return err
+	}
+	return w.countToken()
+}
+
+// WriteRecord writes record as a line of CSV, quoting the fields as specified by
+// RFC 4180 so the output is accepted by [encoding/csv] and spreadsheet software.
+// The fields are separated by commas and the line ends with "\n".
+// It requires the default format of the [Writer].
+func (w *Writer) WriteRecord(record []string) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.format != formatPlain {
+		return newErrWrite("", w.n, errors.New("WriteRecord requires the default format"))
+	}
+	var buf bytes.Buffer
+	cw := csv.NewWriter(&buf)
+	cw.Write(record)
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return newErrWrite("", w.n, err)
+	}
+	w.sep = false
+	if err := w.write(buf.String()); err != nil {
+		return err
+	}
+	return w.countToken()
+}
+
+// writeFormatted writes tok according to the format of w.
+func (w *Writer) writeFormatted(tok Token) error {
+	if tok.Kind == KindDelimiter {
+		return nil
+	}
+	var line string
+	switch w.format {
+	case formatJSONArray:
+		line = "," + jsonString(tok.Text)
+		if w.n == 0 {
+			line = "[" + jsonString(tok.Text)
+		}
+	case formatJSONL:
+		line = "{" + jsonString(w.field) + ":" + jsonString(tok.Text) + "}\n"
+	}
+	if err := w.write(line); err != nil {
+		return err
+	}
+	return w.countToken()
+}
+
+// closeFormat writes what ends the format of w.
+func (w *Writer) closeFormat() error {
+	if w.format != formatJSONArray {
+		return nil
+	}
+	if w.n == 0 {
+		return w.write("[]")
+	}
+	return w.write("]")
+}
+
+// jsonString returns v, a string or a map of strings, encoded in JSON.
+// Unlike [json.Marshal], HTML characters are not escaped.
+func jsonString[T string | map[string]string](v T) string {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.Encode(v)
+	return string(bytes.TrimSuffix(buf.Bytes(), []byte("\n")))
+}
@@ -0,0 +1,27 @@
+package writers
+
+import "expvar"
+
+// WriterStats holds counters of the work done by a [Writer].
+type WriterStats struct {
+	// Tokens is the number of tokens and records written.
+	Tokens int64
+	// Bytes is the number of bytes written, before compression.
+	Bytes int64
+}
+
+// Stats returns the counters of w. It is safe to call while w is writing.
+func (w *Writer) Stats() WriterStats {
+	return WriterStats{Tokens: w.tokens.Load(), Bytes: w.bytes.Load()}
+}
+
+// Var returns an [expvar.Var] reporting the [WriterStats] of w as JSON.
+func (w *Writer) Var() expvar.Var {
+	return expvar.Func(func() any { return w.Stats() })
+}
+
+// PublishExpvar publishes the [WriterStats] of w with [expvar.Publish] under the name
+// prefix + ".writer". Like [expvar.Publish], it panics if the name is already in use.
+func (w *Writer) PublishExpvar(prefix string) {
+	expvar.Publish(prefix+".writer", w.Var())
+}
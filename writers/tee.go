@@ -0,0 +1,70 @@
+package writers
+
+// TokenWriter is the sink counterpart of the TokenSource of package textio.
+//
+// [Writer] implements it, and so can user types receiving tokens,
+// such as database inserters or network clients.
+type TokenWriter interface {
+	// WriteToken writes a single token. Implementations may buffer it
+	// until Flush is called.
+	WriteToken(tok Token) error
+	// Flush writes any buffered token to the underlying sink.
+	Flush() error
+}
+
+// Tee returns a [TokenWriter] writing every token to all of writers, in order.
+// Writing stops at the first error.
+func Tee(writers ...TokenWriter) TokenWriter {
+	return tee(append([]TokenWriter(nil), writers...))
+}
+
+type tee []TokenWriter
+
+func (t tee) WriteToken(tok Token) error {
+	for _, w := range t {
+		if err := w.WriteToken(tok); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t tee) Flush() error {
+	for _, w := range t {
+		if err := w.Flush(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Partition returns a [TokenWriter] writing the content tokens satisfying f to accepted
+// and the others to rejected. A nil f accepts every token. A delimiter token goes to
+// the same writer as the content token it follows.
+func Partition(f func(s string) bool, accepted, rejected TokenWriter) TokenWriter {
+	return &partition{f: f, accepted: accepted, rejected: rejected, last: accepted}
+}
+
+type partition struct {
+	f                  func(s string) bool
+	accepted, rejected TokenWriter
+	// last is the writer of the last content token.
+	last TokenWriter
+}
+
+func (p *partition) WriteToken(tok Token) error {
+	if tok.Kind == KindContent {
+		p.last = p.rejected
+		if p.f == nil || p.f(tok.Text) {
+			p.last = p.accepted
+		}
+	}
+	return p.last.WriteToken(tok)
+}
+
+func (p *partition) Flush() error {
+	if err := p.accepted.Flush(); err != nil {
+		return err
+	}
+	return p.rejected.Flush()
+}
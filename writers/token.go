@@ -0,0 +1,20 @@
+package writers
+
+import "github.com/JFinlayM/textio/internal/core"
+
+// Token is a token to write, along with its kind.
+type Token = core.Token
+
+// TokenKind tells apart content tokens from delimiters.
+type TokenKind = core.TokenKind
+
+const (
+	// KindContent is a token of the content, separated from the previous
+	// content token by the joiner of the delimiter of the [Writer].
+	KindContent = core.KindContent
+	// KindDelimiter is a delimiter, written as is in place of the joiner.
+	KindDelimiter = core.KindDelimiter
+)
+
+// ErrWrite is the kind of the errors reported when writing fails.
+var ErrWrite = core.ErrWrite
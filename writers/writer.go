@@ -0,0 +1,267 @@
+// Package writers provides the [Writer], which writes tokens to an [io.Writer] separated
+// according to a delimiter, and the [TokenWriter] interface it implements, for programs
+// that only need to write tokens.
+//
+// Package textio re-exports the contents of this package under its usual names,
+// such as textio.Writer and textio.Tee.
+package writers
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"io"
+	"sync"
+	"sync/atomic"
+
+	"github.com/JFinlayM/textio/delimiters"
+	"github.com/JFinlayM/textio/internal/core"
+)
+
+// [Writer] writes tokens to an [io.Writer], separated according to its delimiter.
+//
+// Content tokens are separated as with [delimiters.Join]: the joiner of the delimiter is written
+// between two consecutive content tokens. Tokens of kind [KindDelimiter] are written
+// as is and replace the joiner, so the output of a [Reader] emitting its delimiters
+// (see [Delimiter.SetEmitDelimiters]) is reproduced exactly. Content tokens are
+// quoted as with [delimiters.Join] when the delimiter has a quote (see [Delimiter.SetQuote]).
+//
+// Output is buffered: [Writer.Flush] must be called once done writing, or
+// [Writer.Close] when the output is compressed.
+type Writer struct {
+	// out writes to all the sinks of the Writer.
+	out *multiWriter
+	w   *bufio.Writer
+	// FailOnError makes writes fail with [ErrWrite] when a sink fails.
+	// Otherwise the failing sink is dropped and writing goes on with the others.
+	FailOnError bool
+	normalize   func(s string) string
+	// enc encodes the output when set, see [Writer.SetCodec].
+	enc       io.WriteCloser
+	delimiter *delimiters.Delimiter
+	// sep is set when the last token written is a content token,
+	// so the next content token must be preceded by the joiner.
+	sep bool
+	// n is the number of content tokens written, and
+	// tokens and bytes the counters of [Writer.Stats].
+	n             int
+	tokens, bytes atomic.Int64
+	// format is the output format, see [Writer.FormatJSONArray],
+	// and field the token field of the JSON Lines format.
+	format writerFormat
+	field  string
+	// policy decides when buffered tokens are flushed, and pending is the
+	// number of tokens written since the last flush, see [Writer.SetFlushPolicy].
+	policy  FlushPolicy
+	pending int
+	// stop ends the goroutine flushing every policy.Interval.
+	stop chan struct{}
+	// mu serializes writes with interval flushes.
+	mu sync.Mutex
+}
+
+// NewWriter creates a [Writer] writing to w and the optional more writers with
+// the [delimiters.Default] delimiter, so tokens are written one per line.
+// By default, it fails on write errors.
+func NewWriter(w io.Writer, more ...io.Writer) *Writer {
+	tw := &Writer{
+		delimiter:   delimiters.Default(),
+		FailOnError: true,
+	}
+	tw.SetWriters(append([]io.Writer{w}, more...)...)
+	return tw
+}
+
+// SetWriters replaces the sinks of the [Writer] with writers. Every token is written
+// to all of them, in order, as with [io.MultiWriter].
+// It must be called before writing any token, and before [Writer.SetCodec].
+func (w *Writer) SetWriters(writers ...io.Writer) {
+	w.out = &multiWriter{writers: writers, fail: &w.FailOnError}
+	w.w = bufio.NewWriter(w.out)
+	w.enc = nil
+}
+
+// Sets the function to be called to normalize each content token before writing it.
+// There is none by default.
+func (w *Writer) SetNormalizer(normalizeFunc func(s string) string) {
+	w.normalize = normalizeFunc
+}
+
+// Sets the delimiter whose joiner separates the tokens written, see [delimiters.Join].
+func (w *Writer) SetDelimiter(d *delimiters.Delimiter) {
+	w.delimiter = d
+}
+
+// Delimiter returns the delimiter set with [Writer.SetDelimiter].
+func (w *Writer) Delimiter() *delimiters.Delimiter {
+	return w.delimiter
+}
+
+// SetGzip compresses the output with gzip at the given level (see [gzip.NewWriterLevel]),
+// so token streams written to files or storage are compressed without wiring a
+// [gzip.Writer] manually. It must be called before writing any token.
+//
+// [Writer.Flush] then performs a sync flush so the data written so far can be
+// decompressed, and [Writer.Close] must be called to complete the gzip stream.
+func (w *Writer) SetGzip(level int) error {
+	gz, err := gzip.NewWriterLevel(w.out, level)
+	if err != nil {
+		return err
+	}
+	w.setEncoder(gz)
+	return nil
+}
+
+// setEncoder makes w write its output through enc.
+func (w *Writer) setEncoder(enc io.WriteCloser) {
+	w.enc = enc
+	w.w = bufio.NewWriter(enc)
+}
+
+// WriteToken writes tok to the underlying [io.Writer].
+// Errors of the underlying [io.Writer] are reported with [ErrWrite].
+func (w *Writer) WriteToken(tok Token) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.normalize != nil && tok.Kind == KindContent {
+		tok.Text = w.normalize(tok.Text)
+	}
+	if w.format != formatPlain {
+		return w.writeFormatted(tok)
+	}
+	if tok.Kind == KindDelimiter {
+		w.sep = false
+		return w.write(tok.Text)
+	}
+	if w.sep {
+		if err := w.write(core.JoinSep(w.delimiter)); err != nil {
+			return err
+		}
+	}
+	w.sep = true
+	if err := w.write(core.QuoteToken(w.delimiter, tok.Text)); err != nil {
+		return err
+	}
+	return w.countToken()
+}
+
+// WriteString writes s as a content token.
+func (w *Writer) WriteString(s string) error {
+	return w.WriteToken(Token{Text: s})
+}
+
+// WriteTokens writes tokens as content tokens.
+func (w *Writer) WriteTokens(tokens []string) error {
+	for _, token := range tokens {
+		if err := w.WriteString(token); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ConsumeTokens writes the tokens received on in until it is closed, then flushes the
+// [Writer]. It is the counterpart of [Reader.StreamTokens], and returns ctx.Err()
+// if ctx is done first.
+func (w *Writer) ConsumeTokens(ctx context.Context, in <-chan string) error {
+	for {
+		select {
+		case token, ok := <-in:
+			if !ok {
+				return w.Flush()
+			}
+			if err := w.WriteString(token); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Flush writes any buffered data to the underlying [io.Writer].
+func (w *Writer) Flush() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.flush()
+}
+
+func (w *Writer) flush() error {
+	w.pending = 0
+	if err := w.w.Flush(); err != nil {
+		return newErrWrite("", w.n, err)
+	}
+	if f, ok := w.enc.(interface{ Flush() error }); ok {
+		if err := f.Flush(); err != nil {
+			return newErrWrite("", w.n, err)
+		}
+	}
+	return nil
+}
+
+// Close completes the output format, flushes the [Writer] and completes
+// the compressed stream if any. The underlying [io.Writer] is not closed.
+func (w *Writer) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.stopTicker()
+	if err := w.closeFormat(); err != nil {
+		return err
+	}
+	if err := w.flush(); err != nil {
+		return err
+	}
+	if w.enc != nil {
+		if err := w.enc.Close(); err != nil {
+			return newErrWrite("", w.n, err)
+		}
+	}
+	return nil
+}
+
+func (w *Writer) write(s string) error {
+	n, err := w.w.WriteString(s)
+	w.bytes.Add(int64(n))
+	if err != nil {
+		return newErrWrite(s, w.n, err)
+	}
+	return nil
+}
+
+// countToken counts a token written, and flushes according to the flush policy.
+func (w *Writer) countToken() error {
+	w.n++
+	w.tokens.Add(1)
+	w.pending++
+	p := w.policy
+	if p.Tokens > 0 && w.pending >= p.Tokens || p.Bytes > 0 && w.w.Buffered() >= p.Bytes {
+		return w.flush()
+	}
+	return nil
+}
+
+// multiWriter writes to several sinks. Unless fail is set, a failing
+// sink is dropped and its error discarded.
+type multiWriter struct {
+	writers []io.Writer
+	fail    *bool
+}
+
+func (m *multiWriter) Write(p []byte) (int, error) {
+	writers := m.writers[:0]
+	for _, w := range m.writers {
+		n, err := w.Write(p)
+		if err == nil && n < len(p) {
+			err = io.ErrShortWrite
+		}
+		if err != nil {
+			if *m.fail {
+				return n, err
+			}
+			continue
+		}
+		writers = append(writers, w)
+	}
+	m.writers = writers
+	return len(p), nil
+}
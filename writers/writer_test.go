@@ -0,0 +1,49 @@
+package writers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/JFinlayM/textio/delimiters"
+)
+
+func TestWriter(t *testing.T) {
+	var sb strings.Builder
+	w := NewWriter(&sb)
+	w.SetDelimiter(delimiters.CSV())
+	w.WriteTokens([]string{"a", "b, c"})
+	w.WriteToken(Token{Text: "\n", Kind: KindDelimiter})
+	w.WriteString("d")
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if got, want := sb.String(), "a,\"b, c\"\nd"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if st := w.Stats(); st.Tokens != 3 {
+		t.Errorf("Stats().Tokens = %d, want 3", st.Tokens)
+	}
+}
+
+func TestPartition(t *testing.T) {
+	var accepted, rejected strings.Builder
+	a, r := NewWriter(&accepted), NewWriter(&rejected)
+	w := Partition(func(s string) bool { return len(s) > 1 }, a, r)
+	for _, s := range []string{"a", "bb", "c", "dd"} {
+		w.WriteToken(Token{Text: s})
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	if accepted.String() != "bb\ndd" || rejected.String() != "a\nc" {
+		t.Errorf("accepted %q, rejected %q", accepted.String(), rejected.String())
+	}
+
+	var all strings.Builder
+	w = Partition(nil, NewWriter(&all), r)
+	w.WriteToken(Token{Text: "x"})
+	w.Flush()
+	if all.String() != "x" {
+		t.Errorf("nil filter: accepted %q, want %q", all.String(), "x")
+	}
+}
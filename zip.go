@@ -0,0 +1,104 @@
+package textio
+
+// ZipPolicy is what a [Zipper] does when its sources differ in length.
+type ZipPolicy int
+
+const (
+	// ZipStrict fails with [ErrLengthMismatch].
+	ZipStrict ZipPolicy = iota
+	// ZipShortest stops at the end of the shortest source.
+	ZipShortest
+	// ZipLongest goes on until the end of the longest source,
+	// pairing its tokens with empty strings.
+	ZipLongest
+)
+
+// Zip reads a and b in lockstep and returns their tokens in pairs, for example to
+// consume the source and target files of a translation corpus. Both sources must
+// have the same length, otherwise [ErrLengthMismatch] is returned along with the
+// pairs read so far. Use a [Zipper] to choose another policy or to stream the pairs.
+func Zip(a, b TokenSource) ([][2]string, error) {
+	return NewZipper(a, b, ZipStrict).All()
+}
+
+// [Zipper] reads two token sources in lockstep, one pair of tokens at a time.
+type Zipper struct {
+	a, b   TokenSource
+	policy ZipPolicy
+	pair   [2]string
+	index  int
+	err    error
+	done   bool
+}
+
+// NewZipper creates a [Zipper] over a and b applying policy when their lengths differ.
+func NewZipper(a, b TokenSource, policy ZipPolicy) *Zipper {
+	return &Zipper{a: a, b: b, policy: policy}
+}
+
+// Scan advances to the next pair, which is then available through [Zipper.Pair].
+// It returns false at the end of the pairs or on error.
+func (z *Zipper) Scan() bool {
+	if z.done {
+		return false
+	}
+	okA, okB := z.a.Scan(), z.b.Scan()
+	if err := z.a.Err(); err != nil {
+		return z.fail(err)
+	}
+	if err := z.b.Err(); err != nil {
+		return z.fail(err)
+	}
+
+	switch {
+	case okA && okB:
+		z.pair = [2]string{z.a.Text(), z.b.Text()}
+	case !okA && !okB:
+		z.done = true
+		return false
+	case z.policy == ZipStrict:
+		return z.fail(newErrLengthMismatch(z.index))
+	case z.policy == ZipShortest:
+		z.done = true
+		z.close()
+		return false
+	case okA:
+		z.pair = [2]string{z.a.Text(), ""}
+	default:
+		z.pair = [2]string{"", z.b.Text()}
+	}
+	z.index++
+	return true
+}
+
+func (z *Zipper) fail(err error) bool {
+	z.err, z.done = err, true
+	z.close()
+	return false
+}
+
+// close closes the sources, which may be abandoned before their end.
+func (z *Zipper) close() {
+	closeSource(z.a)
+	closeSource(z.b)
+}
+
+// Pair returns the pair read by the last call to [Zipper.Scan].
+func (z *Zipper) Pair() [2]string {
+	return z.pair
+}
+
+// Err returns the first error encountered by [Zipper.Scan].
+func (z *Zipper) Err() error {
+	return z.err
+}
+
+// All reads every remaining pair. On error, the pairs read
+// before the failure are returned along with the error.
+func (z *Zipper) All() ([][2]string, error) {
+	var pairs [][2]string
+	for z.Scan() {
+		pairs = append(pairs, z.Pair())
+	}
+	return pairs, z.Err()
+}